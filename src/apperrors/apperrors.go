@@ -11,15 +11,15 @@ type ErrorKind int
 
 type AppError struct {
 	message string
-	kind ErrorKind
-	source error
+	kind    ErrorKind
+	source  error
 }
 
 func (e *AppError) Error() string {
 	if e.source != nil {
 		return fmt.Sprintf("%s:%s", e.message, e.source.Error())
 	}
-	
+
 	return fmt.Sprintf(e.message)
 }
 
@@ -29,7 +29,7 @@ func (e *AppError) Message() string {
 
 func Test() error {
 	return &AppError{}
-  }
+}
 
 func (e *AppError) IsKind(kind ErrorKind) bool {
 	if e.kind == kind {
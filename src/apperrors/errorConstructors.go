@@ -7,25 +7,25 @@ import (
 /* Public constructors of app errors for each type of error the app can experience */
 
 // Dao DB + query execution errors
-func DBConnectionError(source error) *AppError{
+func DBConnectionError(source error) *AppError {
 	message := fmt.Sprintf("Cannot connect to the DB.")
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: source}
 }
 
-func NoSQLFileMappedToId(identifer string, source error) *AppError{
+func NoSQLFileMappedToId(identifer string, source error) *AppError {
 	message := fmt.Sprintf("There is no mapping of the identifer %s to a SQL file.", identifer)
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: source}
 }
 
-func SQLFileReadError(sqlFileName string, source error) *AppError{
+func SQLFileReadError(sqlFileName string, source error) *AppError {
 	message := fmt.Sprintf("Cannot read given SQL file %s due to error %s", sqlFileName, source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: source}
 }
 
-func QueryExecutionError(source error) *AppError{
+func QueryExecutionError(source error) *AppError {
 	message := fmt.Sprintf("Could not run query due to error:%s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: source}
@@ -37,7 +37,7 @@ func CannotGetMetrics(source error) *AppError {
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func NoRows() *AppError{
+func NoRows() *AppError {
 	message := fmt.Sprintf("No rows returns by query.")
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
@@ -63,55 +63,85 @@ func CountyIDNotFound(county int) *AppError {
 	return &a
 }
 
-func StateIDNotFound(state_id int) *AppError{
+func TaxLocaleNotFound(locale_id int, county string) *AppError {
+	message := fmt.Sprintf("Tax locale %v is not in county %s", locale_id, county)
+	kind := DataNotFound
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func StateIDNotFound(state_id int) *AppError {
 	message := fmt.Sprintf("State ID not in state cache %v", state_id)
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func StateNameNotFound(state_name string) *AppError{
+func StateNameNotFound(state_name string) *AppError {
 	message := fmt.Sprintf("State name not in state cache %s", state_name)
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func StateIDNotInTaxCache(state_id int) *AppError{
+func StateIDNotInTaxCache(state_id int) *AppError {
 	message := fmt.Sprintf("State ID not in state tax cache %v", state_id)
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func StateNameNotInTaxCache(state_name string) *AppError{
+func StateNameNotInTaxCache(state_name string) *AppError {
 	message := fmt.Sprintf("State name not in state tax cache %s", state_name)
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func InvalidStateMetric(metric_name string) *AppError{
+func TaxYearNotFound(year int) *AppError {
+	message := fmt.Sprintf("Tax year %v is not in the tax cache", year)
+	kind := DataNotFound
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func InvalidStateMetric(metric_name string) *AppError {
 	message := fmt.Sprintf("The provided metric %s is not in the state cache", metric_name)
 	kind := DataNotFound
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
 // Internal errors involving accessing domain
-func UnableToGetStateCensus(source error) *AppError{
+func UnableToGetStateCensus(source error) *AppError {
 	message := fmt.Sprintf("Unable to retrieve state census data from DB: %s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func UnableToGetStateTax(source error) *AppError{
+func UnableToGetStateTax(source error) *AppError {
 	message := fmt.Sprintf("Unable to retrieve state tax data from DB: %s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
+func UnableToGetStateReciprocity(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve state reciprocity agreements from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func UnableToGetSalesTaxRates(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve sales tax rates from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
 func UnableToGetCountyList(source error) *AppError {
 	message := fmt.Sprintf("Unable to retrieve county list from DB: %s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
+func UnableToGetAllCountyData(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve data for all counties from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
 func UnableToGetCountyName(county string, source error) *AppError {
 	message := fmt.Sprintf("Unable to retrieve data for county %s: %s", county, source.Error())
 	kind := InternalError
@@ -125,19 +155,37 @@ func UnableToGetCountyID(county int, source error) *AppError {
 	return &a
 }
 
-func UnableToGetFederalTax(source error) *AppError{
+func UnableToGetFederalTax(source error) *AppError {
 	message := fmt.Sprintf("Unable to retrieve federal tax data from DB: %s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: nil}
 }
 
-func EmptyFederalCache() *AppError{
+func UnableToGetFederalParameters(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve federal parameters from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func UnableToGetEarnedIncomeCreditSchedules(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve earned income credit schedules from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func UnableToGetTaxYears(source error) *AppError {
+	message := fmt.Sprintf("Unable to retrieve tax years from DB: %s", source.Error())
+	kind := InternalError
+	return &AppError{message: message, kind: kind, source: nil}
+}
+
+func EmptyFederalCache() *AppError {
 	return &AppError{message: "The federal tax cache is empty", kind: InternalError, source: nil}
 }
 
 // marhshalling errors
-func UnableToMarshall(source error) *AppError{
+func UnableToMarshall(source error) *AppError {
 	message := fmt.Sprintf("Unable to marshall response object: %s", source.Error())
 	kind := InternalError
 	return &AppError{message: message, kind: kind, source: source}
-}
\ No newline at end of file
+}
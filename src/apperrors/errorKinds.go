@@ -5,4 +5,4 @@ package apperrors
 const (
 	DataNotFound ErrorKind = iota
 	InternalError
-)
\ No newline at end of file
+)
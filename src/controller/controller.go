@@ -4,6 +4,7 @@ package controller
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -31,7 +32,7 @@ func CountyHandler(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Get county called")
 	start := time.Now()
 	// params
-	id, name, fs, res, dep, income, errStr := getCountyParams(r)
+	id, name, filer, explain, errStr := getCountyParams(r)
 	if errStr != "" {
 		writeGotBadParams(w, errStr)
 		return
@@ -47,15 +48,20 @@ func CountyHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
 	// call the appropriate service method based on the provided params
 	var county *model.County
 	var err *apperrors.AppError
 	if name != "" {
 		logger.Info("Getting county", name)
-		county, err = countyService.GetCountyByName(name, fs, res, dep, income)
+		county, err = countyService.GetCountyByName(name, filer, explain)
 	} else {
 		logger.Info("Getting county %v", id)
-		county, err = countyService.GetCountyById(id, fs, res, dep, income)
+		county, err = countyService.GetCountyById(id, filer, explain)
 	}
 
 	// check errors, write the response based on county value
@@ -81,7 +87,7 @@ func StateHandler(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Get state called")
 	start := time.Now()
 	// params
-	id, name, fs, _, dep, income, errStr := getStateParams(r)
+	id, name, filer, explain, errStr := getStateParams(r)
 	if errStr != "" {
 		writeGotBadParams(w, errStr)
 		return
@@ -97,15 +103,20 @@ func StateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
 	// call the appropriate service method
 	var state *model.State
 	var err *apperrors.AppError
 	if name != "" {
 		logger.Info("Getting state", name)
-		state, err = stateService.GetStateByName(name, fs, dep, income)
+		state, err = stateService.GetStateByName(name, filer, explain)
 	} else {
 		logger.Info("Getting state %v", id)
-		state, err = stateService.GetStateById(id, fs, dep, income)
+		state, err = stateService.GetStateById(id, filer, explain)
 	}
 
 	// check errors, write the response based on state value
@@ -126,12 +137,471 @@ func StateHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handle get requests for the take-home pay per paycheck in a county
+func PaycheckHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get paycheck called")
+	start := time.Now()
+	// params
+	id, name, filer, errStr := getPaycheckParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// call the appropriate service method based on the provided params
+	var paycheck *model.Paycheck
+	var err *apperrors.AppError
+	if name != "" {
+		logger.Info("Getting paycheck for county", name)
+		paycheck, err = countyService.GetPaycheckByName(name, filer)
+	} else {
+		logger.Info("Getting paycheck for county %v", id)
+		paycheck, err = countyService.GetPaycheckById(id, filer)
+	}
+
+	// check errors, write the response based on paycheck value
+	if err != nil {
+		if err.IsKind(apperrors.DataNotFound) {
+			writeNoEntityAvailable(w, isGet, "county", nameOrId(name, id))
+		} else if err.IsKind(apperrors.InternalError) || err != nil {
+			writeUnableToGetEntity(w, err, isGet, "county", nameOrId(name, id))
+		}
+	} else {
+		b, err := paycheck.MarshallPaycheck()
+		if err != nil {
+			writeGotMarshallError(w, err, isGet, "county", nameOrId(name, id))
+		} else {
+			write200Response(w, isGet, start, b)
+		}
+
+	}
+}
+
+// handle get requests for the taxes of commuting from a home county to a work county
+func CommuteHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get commute called")
+	start := time.Now()
+	// params
+	homeId, homeName, workId, workName, filer, explain, errStr := getCommuteParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// get the tax info of the home and work counties
+	logger.Info("Getting tax information for home county %s", nameOrId(homeName, homeId))
+	home, err := getCountyTaxList(homeName, homeId)
+	if err != nil {
+		writeCountyError(w, err, isGet, nameOrId(homeName, homeId))
+		return
+	}
+	logger.Info("Getting tax information for work county %s", nameOrId(workName, workId))
+	work, err := getCountyTaxList(workName, workId)
+	if err != nil {
+		writeCountyError(w, err, isGet, nameOrId(workName, workId))
+		return
+	}
+
+	commute, err := countyService.GetCommute(home, work, filer, explain)
+	if err != nil {
+		writeCountyError(w, err, isGet, nameOrId(homeName, homeId))
+		return
+	}
+	b, err := commute.MarshallCommute()
+	if err != nil {
+		writeGotMarshallError(w, err, isGet, "county", nameOrId(homeName, homeId))
+	} else {
+		write200Response(w, isGet, start, b)
+	}
+}
+
+// handle get requests for the taxes of a household whose earners may work in different counties
+func HouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get household called")
+	start := time.Now()
+	// params
+	homeId, homeName, earnerParams, filer, explain, errStr := getHouseholdParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// get the tax info of the home county and the county each earner works in
+	logger.Info("Getting tax information for home county %s", nameOrId(homeName, homeId))
+	home, err := getCountyTaxList(homeName, homeId)
+	if err != nil {
+		writeCountyError(w, err, isGet, nameOrId(homeName, homeId))
+		return
+	}
+	earners := []model.EarnerProfile{}
+	for _, e := range earnerParams {
+		logger.Info("Getting tax information for work county %s", nameOrId(e.workName, e.workId))
+		work, err := getCountyTaxList(e.workName, e.workId)
+		if err != nil {
+			writeCountyError(w, err, isGet, nameOrId(e.workName, e.workId))
+			return
+		}
+		earners = append(earners, model.EarnerProfile{Income: e.income, Work: work})
+	}
+
+	household, err := countyService.GetHousehold(home, earners, filer, explain)
+	if err != nil {
+		writeCountyError(w, err, isGet, nameOrId(homeName, homeId))
+		return
+	}
+	b, err := household.MarshallHousehold()
+	if err != nil {
+		writeGotMarshallError(w, err, isGet, "county", nameOrId(homeName, homeId))
+	} else {
+		write200Response(w, isGet, start, b)
+	}
+}
+
+// handle get requests comparing the taxes and census metrics of moving from an origin region to a destination region
+func CompareHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get compare called")
+	start := time.Now()
+	// params
+	origin, destination, filer, errStr := getCompareParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// estimate both regions for the same filer
+	originRegion, projected, ok := getComparisonRegion(w, isGet, origin, filer)
+	if !ok {
+		return
+	}
+	destinationRegion, _, ok := getComparisonRegion(w, isGet, destination, filer)
+	if !ok {
+		return
+	}
+
+	comparison := model.GetComparison(originRegion, destinationRegion, filer.Tax_year, projected)
+	b, err := comparison.MarshallComparison()
+	if err != nil {
+		writeGotMarshallError(w, err, isGet, origin.regionType, nameOrId(origin.name, origin.id))
+	} else {
+		write200Response(w, isGet, start, b)
+	}
+}
+
+// helper function to get a county or state reduced to the metrics compared between regions, along with whether its
+// estimates use projected tax tables. Writes the response and returns false when the region or tax locale is not found
+func getComparisonRegion(w http.ResponseWriter, isGet bool, p regionParams, filer model.FilerProfile) (model.ComparisonRegion, bool, bool) {
+	identifier := nameOrId(p.name, p.id)
+	logger.Info("Getting %s %s for comparison", p.regionType, identifier)
+
+	region, projected, localeFound, err := fetchComparisonRegion(p, filer)
+	if err != nil {
+		writeRegionError(w, err, isGet, p.regionType, identifier)
+		return model.ComparisonRegion{}, false, false
+	}
+	if !localeFound {
+		writeNoEntityAvailable(w, isGet, "tax locale", fmt.Sprintf("%v in county %s", p.localeId, identifier))
+		return model.ComparisonRegion{}, false, false
+	}
+
+	return region, projected, true
+}
+
+// helper function to estimate a county or state for the filer and reduce it to the metrics compared between regions.
+// Also returns whether the estimates use projected tax tables, and whether the requested tax locale of a county exists
+func fetchComparisonRegion(p regionParams, filer model.FilerProfile) (model.ComparisonRegion, bool, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	if p.regionType == model.StateRegion {
+		var state *model.State
+		if p.name != "" {
+			state, err = stateService.GetStateByName(p.name, filer, false)
+		} else {
+			state, err = stateService.GetStateById(p.id, filer, false)
+		}
+		if err != nil {
+			return model.ComparisonRegion{}, false, false, err
+		}
+
+		return model.GetStateComparisonRegion(state), state.Projected, true, nil
+	}
+
+	var county *model.County
+	if p.name != "" {
+		county, err = countyService.GetCountyByName(p.name, filer, false)
+	} else {
+		county, err = countyService.GetCountyById(p.id, filer, false)
+	}
+	if err != nil {
+		return model.ComparisonRegion{}, false, false, err
+	}
+
+	// the first tax locale of the county is compared unless one is requested
+	if !p.localeGiven {
+		return model.GetDefaultCountyComparisonRegion(county), county.Projected, true, nil
+	}
+	region, ok := model.GetCountyComparisonRegion(county, p.localeId)
+
+	return region, county.Projected, ok, nil
+}
+
+// handle get requests for the income in a destination region that leaves the same disposable income, after taxes and
+// rent, as the given income in an origin region
+func SalaryEquivalenceHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get salary equivalence called")
+	start := time.Now()
+	// params, the same regions and tax filer variables as a comparison
+	origin, destination, filer, errStr := getCompareParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// estimate the origin at the given income, and check the destination can be estimated before searching
+	originRegion, _, ok := getComparisonRegion(w, isGet, origin, filer)
+	if !ok {
+		return
+	}
+	if _, _, ok = getComparisonRegion(w, isGet, destination, filer); !ok {
+		return
+	}
+
+	// search the incomes of the destination for the disposable income of the origin
+	equivalence, found, err := fetchSalaryEquivalence(destination, originRegion, filer)
+	if err != nil {
+		writeRegionError(w, err, isGet, destination.regionType, nameOrId(destination.name, destination.id))
+		return
+	}
+	if !found {
+		writeNoEntityAvailable(w, isGet, "equivalent income in", nameOrId(destination.name, destination.id))
+		return
+	}
+
+	b, err := equivalence.MarshallSalaryEquivalence()
+	if err != nil {
+		writeGotMarshallError(w, err, isGet, destination.regionType, nameOrId(destination.name, destination.id))
+	} else {
+		write200Response(w, isGet, start, b)
+	}
+}
+
+// helper function to get the salary equivalence of an origin region in a destination county or state by name if one is
+// given, otherwise by id. Also returns false when no income reaches the disposable income of the origin
+func fetchSalaryEquivalence(p regionParams, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	if p.regionType == model.StateRegion {
+		if p.name != "" {
+			return stateService.GetSalaryEquivalenceByName(p.name, origin, filer)
+		}
+		return stateService.GetSalaryEquivalenceById(p.id, origin, filer)
+	}
+
+	if p.name != "" {
+		return countyService.GetSalaryEquivalenceByName(p.name, p.localeId, p.localeGiven, origin, filer)
+	}
+	return countyService.GetSalaryEquivalenceById(p.id, p.localeId, p.localeGiven, origin, filer)
+}
+
+// handle get requests for the gross income needed in a county or state to take home a target net income or net pay
+func GrossUpHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Get gross up called")
+	start := time.Now()
+	// params
+	p, filer, errStr := getGrossUpParams(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
+	// http method validation
+	isGet, isOption, errStr := getHTTPMethod(r)
+	if isOption {
+		writePreFlightRequest(w)
+		return
+	}
+	if errStr != "" {
+		writeStatusNotImpl(w, errStr)
+		return
+	}
+
+	// the estimates are made with the tax tables of the requested year
+	if !resolveTaxYear(w, isGet, &filer) {
+		return
+	}
+
+	// a net pay per paycheck is taken home over each pay period of the year
+	target := p.netIncome
+	if p.perPaycheck {
+		target = int(math.Ceil(p.netPay * float64(filer.Pay_frequency.GetPayPeriods())))
+	}
+
+	// check the region can be estimated before searching
+	if _, _, ok := getComparisonRegion(w, isGet, p.region, filer); !ok {
+		return
+	}
+
+	// search the gross incomes of the region for the target net income
+	grossUp, found, err := fetchGrossUp(p.region, target, p.tolerance, filer)
+	if err != nil {
+		writeRegionError(w, err, isGet, p.region.regionType, nameOrId(p.region.name, p.region.id))
+		return
+	}
+	if !found {
+		writeNoEntityAvailable(w, isGet, "gross income in", nameOrId(p.region.name, p.region.id))
+		return
+	}
+	if p.perPaycheck {
+		grossUp.SetPaycheck(filer.Pay_frequency, p.netPay)
+	}
+
+	b, err := grossUp.MarshallGrossUp()
+	if err != nil {
+		writeGotMarshallError(w, err, isGet, p.region.regionType, nameOrId(p.region.name, p.region.id))
+	} else {
+		write200Response(w, isGet, start, b)
+	}
+}
+
+// helper function to get the gross up of a target net income in a county or state by name if one is given, otherwise
+// by id. Also returns false when no income reaches the target
+func fetchGrossUp(p regionParams, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError) {
+	if p.regionType == model.StateRegion {
+		if p.name != "" {
+			return stateService.GetGrossUpByName(p.name, target, tolerance, filer)
+		}
+		return stateService.GetGrossUpById(p.id, target, tolerance, filer)
+	}
+
+	if p.name != "" {
+		return countyService.GetGrossUpByName(p.name, p.localeId, p.localeGiven, target, tolerance, filer)
+	}
+	return countyService.GetGrossUpById(p.id, p.localeId, p.localeGiven, target, tolerance, filer)
+}
+
+// helper function to resolve the requested tax year of the filer, 0 resolves to the default year. Writes the
+// response and returns false when the year is not loaded
+func resolveTaxYear(w http.ResponseWriter, isGet bool, filer *model.FilerProfile) bool {
+	year, err := federalService.GetTaxYear(filer.Tax_year)
+	if err != nil {
+		writeNoEntityAvailable(w, isGet, "tax year", fmt.Sprint(filer.Tax_year))
+		return false
+	}
+	filer.Tax_year = year
+
+	return true
+}
+
+// helper function to get the tax info of a county by name if one is given, otherwise by id
+func getCountyTaxList(name string, id int) (*model.CountyTaxList, *apperrors.AppError) {
+	if name != "" {
+		return countyService.GetCountyTaxListByName(name)
+	}
+
+	return countyService.GetCountyTaxListById(id)
+}
+
+// helper function to write the response for an error retrieving a county
+func writeCountyError(w http.ResponseWriter, err *apperrors.AppError, isGet bool, identifier string) {
+	writeRegionError(w, err, isGet, "county", identifier)
+}
+
+// helper function to write the response for an error retrieving a county or state
+func writeRegionError(w http.ResponseWriter, err *apperrors.AppError, isGet bool, entity, identifier string) {
+	if err.IsKind(apperrors.DataNotFound) {
+		writeNoEntityAvailable(w, isGet, entity, identifier)
+	} else {
+		writeUnableToGetEntity(w, err, isGet, entity, identifier)
+	}
+}
+
+// county lists ranked by a census metric or a computed metric, which are marshalled the same way
+type countyListMarshaller interface {
+	MarshallCountyList() ([]byte, *apperrors.AppError)
+}
+
 // handle get requests for ranked list of counties by a given metric
 func CountyListHandler(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Get county list called")
 	start := time.Now()
 	// params
 	metricName, size, desc, errStr := getListParams(r)
+	// computed metrics are estimated for the tax filer variables
+	computed := errStr == "" && model.IsComputedCountyMetric(metricName)
+	var filer model.FilerProfile
+	if computed {
+		filer, errStr = getCountyListFilerParams(r)
+	}
 	if errStr != "" {
 		writeGotBadParams(w, errStr)
 		return
@@ -149,7 +619,17 @@ func CountyListHandler(w http.ResponseWriter, r *http.Request) {
 
 	// get the county list
 	logger.Info("Getting county list for metric %s", metricName)
-	countyList, err := countyService.GetCountyList(metricName, size, desc)
+	var countyList countyListMarshaller
+	var err *apperrors.AppError
+	if computed {
+		// the metrics are computed with the tax tables of the requested year
+		if !resolveTaxYear(w, isGet, &filer) {
+			return
+		}
+		countyList, err = countyService.GetComputedCountyList(metricName, size, desc, filer)
+	} else {
+		countyList, err = countyService.GetCountyList(metricName, size, desc)
+	}
 
 	// write the response based on county value
 	if err != nil {
@@ -274,6 +754,7 @@ func StateTaxesHandler(w http.ResponseWriter, r *http.Request) {
 	// params
 	idStr := r.URL.Query().Get("id")
 	name := r.URL.Query().Get("name")
+	year, yearErrStr := getYearParam(r)
 	// http method validation
 	isGet, isOption, errStr := getHTTPMethod(r)
 	if isOption {
@@ -285,6 +766,15 @@ func StateTaxesHandler(w http.ResponseWriter, r *http.Request) {
 	} else if idStr == "" && name == "" {
 		writeGotBadParams(w, "A state id or name must be provided to retrieve tax information.")
 		return
+	} else if yearErrStr != "" {
+		writeGotBadParams(w, yearErrStr)
+		return
+	}
+
+	// validate the year before the state so an unknown year is not reported as an unknown state
+	if _, err := federalService.GetTaxYear(year); err != nil {
+		writeNoEntityAvailable(w, isGet, "tax year", fmt.Sprint(year))
+		return
 	}
 
 	var stateTaxInfo *model.StateTaxInfo
@@ -292,12 +782,12 @@ func StateTaxesHandler(w http.ResponseWriter, r *http.Request) {
 	var id int
 	if name != "" {
 		logger.Info("Getting tax information for state %s", name)
-		stateTaxInfo, err = stateService.GetStateTaxInfoByName(name)
+		stateTaxInfo, err = stateService.GetStateTaxInfoByName(name, year)
 	} else if idStr != "" {
 		id, convErr := strconv.Atoi(idStr)
 		if convErr == nil {
 			logger.Info("Getting tax information for state %v", id)
-			stateTaxInfo, err = stateService.GetStateTaxInfoById(id)
+			stateTaxInfo, err = stateService.GetStateTaxInfoById(id, year)
 		} else {
 			writeNoEntityAvailable(w, isGet, "state", fmt.Sprint(id))
 			return
@@ -324,6 +814,12 @@ func StateTaxesHandler(w http.ResponseWriter, r *http.Request) {
 func FederalTaxesHandler(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Get federal tax info called")
 	start := time.Now()
+	// params
+	year, errStr := getYearParam(r)
+	if errStr != "" {
+		writeGotBadParams(w, errStr)
+		return
+	}
 	// http method validation
 	isGet, isOption, errStr := getHTTPMethod(r)
 	if isOption {
@@ -335,12 +831,15 @@ func FederalTaxesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info("Getting federal tax info")
-	federlTaxInfo, err := federalService.GetFederalTaxInfo()
+	logger.Info("Getting federal tax info for %v", year)
+	federlTaxInfo, err := federalService.GetFederalTaxInfo(year)
 	if err != nil {
-		if err.IsKind(apperrors.InternalError) || err != nil {
+		if err.IsKind(apperrors.DataNotFound) {
+			writeNoEntityAvailable(w, isGet, "tax year", fmt.Sprint(year))
+		} else if err.IsKind(apperrors.InternalError) || err != nil {
 			writeResponse(w, isGet, http.StatusInternalServerError, []byte("Unable to retrieve federal tax information due to an internal error."))
 		}
+		return
 	}
 	b, err := federlTaxInfo.MarshallFederalTaxInfo()
 	if err != nil {
@@ -18,7 +18,7 @@ var stateService services.StateServiceInterface = nil
 var countyService services.CountyServiceInterface = nil
 
 // public method called to initialize services if they have not been initilized
-func InitServices(user, password, dbName, dbHost, dbPort string) error {
+func InitServices(user, password, dbName, dbHost, dbPort string, cpiFactor float64) error {
 	// initialize any nil services in order of dependency
 	var err *apperrors.AppError = nil
 	if daoImpl == nil {
@@ -32,7 +32,7 @@ func InitServices(user, password, dbName, dbHost, dbPort string) error {
 	}
 
 	if federalService == nil {
-		federalService, err = services.GetFederalServiceImpl(daoImpl)
+		federalService, err = services.GetFederalServiceImpl(daoImpl, cpiFactor)
 		if err != nil {
 			logger.Error("Could not initialize federal service")
 			return err
@@ -4,20 +4,31 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Matthew-Curry/re-region-api/src/model"
 )
 
 /* Holds validator functions used by the controllers */
 
-func getCountyParams(r *http.Request) (int, string, model.FilingStatus, bool, int, int, string) {
+func getCountyParams(r *http.Request) (int, string, model.FilerProfile, bool, string) {
 	return getGeoParams("county", r)
 }
 
-func getStateParams(r *http.Request) (int, string, model.FilingStatus, bool, int, int, string) {
+func getStateParams(r *http.Request) (int, string, model.FilerProfile, bool, string) {
 	return getGeoParams("state", r)
 }
 
+// the county and tax filer variables of a paycheck request, only employees are paid through paychecks
+func getPaycheckParams(r *http.Request) (int, string, model.FilerProfile, string) {
+	id, name, filer, _, errorStr := getGeoParams("county", r)
+	if filer.Employment_type == model.SelfEmployed {
+		errorStr = errorStr + "\nPaychecks can only be estimated for employees."
+	}
+
+	return id, name, filer, errorStr
+}
+
 func getListParams(r *http.Request) (string, int, bool, string) {
 	metric := r.URL.Query().Get("metric_name")
 	sizeStr := r.URL.Query().Get("size")
@@ -45,24 +56,25 @@ func getListParams(r *http.Request) (string, int, bool, string) {
 
 }
 
-func getGeoParams(geo string, r *http.Request) (int, string, model.FilingStatus, bool, int, int, string) {
+// the tax filer variables a computed county list metric is estimated for. The filer lives in each county
+// ranked, so is a resident of it
+func getCountyListFilerParams(r *http.Request) (model.FilerProfile, string) {
+	filer, errorStr := getFilerParams(r)
+	filer.Resident = true
+
+	return filer, errorStr
+}
+
+func getGeoParams(geo string, r *http.Request) (int, string, model.FilerProfile, bool, string) {
 	// concat issues with parametes as encountered for the response
 	errorStr := ""
 
 	// read in the expected parameters as strings
 	idStr := r.URL.Query().Get("id")
 	name := r.URL.Query().Get("name")
-	fsStr := r.URL.Query().Get("filingStatus")
-	resStr := r.URL.Query().Get("residencyStatus")
-	depStr := r.URL.Query().Get("dependents")
-	incomeStr := r.URL.Query().Get("income")
 
 	// non string vars
 	var id int
-	var fs model.FilingStatus
-	var res bool
-	var dep int
-	var income int
 
 	// error
 	var err error
@@ -79,29 +91,387 @@ func getGeoParams(geo string, r *http.Request) (int, string, model.FilingStatus,
 		}
 	}
 
-	// fs must be valid
-	fs, err = model.ToFilingStatus(fsStr)
-	if err != nil {
-		errorStr = errorStr + "\nThe provided filing status must indicate 'S', 'H', or 'M'."
-	}
+	// the tax filer variables
+	filer, filerErrorStr := getFilerParams(r)
+	errorStr = errorStr + filerErrorStr
 
 	// res must be interpretable as bool
-	res, err = strconv.ParseBool(resStr)
+	filer.Resident, err = strconv.ParseBool(r.URL.Query().Get("residencyStatus"))
 	if err != nil {
 		errorStr = errorStr + "\nThe provided resident flag must be interpretable as a boolean"
 	}
 
+	explain, explainErrorStr := getExplainParam(r)
+	errorStr = errorStr + explainErrorStr
+
+	return id, name, filer, explain, errorStr
+}
+
+// the home and work counties and tax filer variables of a commute request. The filer is a resident of the home
+// county and a nonresident of the work county, so no residency status is read
+func getCommuteParams(r *http.Request) (int, string, int, string, model.FilerProfile, bool, string) {
+	// a name or id must be provided for both counties
+	homeId, homeName, homeGiven, errorStr := getCountyIdentifier("home", "home", r)
+	if !homeGiven {
+		errorStr = errorStr + "\nA home county name or id must be provided."
+	}
+	workId, workName, workGiven, workErrorStr := getCountyIdentifier("work", "work", r)
+	errorStr = errorStr + workErrorStr
+	if !workGiven {
+		errorStr = errorStr + "\nA work county name or id must be provided."
+	}
+
+	// the tax filer variables
+	filer, filerErrorStr := getFilerParams(r)
+	errorStr = errorStr + filerErrorStr
+
+	explain, explainErrorStr := getExplainParam(r)
+	errorStr = errorStr + explainErrorStr
+
+	return homeId, homeName, workId, workName, filer, explain, errorStr
+}
+
+// the wages and work county of an earner in a household request
+type earnerParams struct {
+	income   int
+	workId   int
+	workName string
+}
+
+// the home county, earners, and tax filer variables of a household request. The income and work county parameters
+// describe the first earner and the spouse parameters an optional second earner, whose household must file jointly.
+// Earners without a work county work in the home county
+func getHouseholdParams(r *http.Request) (int, string, []earnerParams, model.FilerProfile, bool, string) {
+	homeId, homeName, homeGiven, errorStr := getCountyIdentifier("home", "home", r)
+	if !homeGiven {
+		errorStr = errorStr + "\nA home county name or id must be provided."
+	}
+
+	// the tax filer variables, the income of which is the wages of the first earner
+	filer, filerErrorStr := getFilerParams(r)
+	errorStr = errorStr + filerErrorStr
+
+	earner := earnerParams{income: filer.Income, workId: homeId, workName: homeName}
+	if workId, workName, workGiven, workErrorStr := getCountyIdentifier("work", "work", r); workGiven {
+		earner.workId, earner.workName = workId, workName
+		errorStr = errorStr + workErrorStr
+	}
+	earners := []earnerParams{earner}
+
+	// the spouse is optional, and is only given for married filers
+	spouseIncomeStr := r.URL.Query().Get("spouseIncome")
+	if spouseIncomeStr != "" {
+		spouse := earnerParams{workId: homeId, workName: homeName}
+		var err error
+		spouse.income, err = strconv.Atoi(spouseIncomeStr)
+		if err != nil {
+			errorStr = errorStr + "\nThe provided spouse income must be an integer."
+		}
+		if filer.Filing_status != model.Married {
+			errorStr = errorStr + "\nA spouse income can only be provided for the married filing status."
+		}
+		if workId, workName, workGiven, workErrorStr := getCountyIdentifier("spouseWork", "spouse work", r); workGiven {
+			spouse.workId, spouse.workName = workId, workName
+			errorStr = errorStr + workErrorStr
+		}
+		earners = append(earners, spouse)
+	}
+
+	explain, explainErrorStr := getExplainParam(r)
+	errorStr = errorStr + explainErrorStr
+
+	return homeId, homeName, earners, filer, explain, errorStr
+}
+
+// the origin and destination regions and tax filer variables of a compare request. The filer is estimated as a
+// resident of each region
+func getCompareParams(r *http.Request) (regionParams, regionParams, model.FilerProfile, string) {
+	origin, errorStr := getRegionParams("origin", r)
+	destination, destinationErrorStr := getRegionParams("destination", r)
+	errorStr = errorStr + destinationErrorStr
+
+	// the tax filer variables
+	filer, filerErrorStr := getFilerParams(r)
+	errorStr = errorStr + filerErrorStr
+	filer.Resident = true
+
+	return origin, destination, filer, errorStr
+}
+
+// tolerance in dollars of the net income reached by a gross up, used when none is given
+const defaultGrossUpTolerance = 1
+
+// the region and target of a gross up request, the target is either an annual net income or a net pay per paycheck
+type grossUpParams struct {
+	region      regionParams
+	netIncome   int
+	netPay      float64
+	perPaycheck bool
+	tolerance   int
+}
+
+// the region, target net amount, and tax filer variables of a gross up request. The income is solved for, so it is
+// not read, and the filer is estimated as a resident of the region
+func getGrossUpParams(r *http.Request) (grossUpParams, model.FilerProfile, string) {
+	region, errorStr := getRegionParams("region", r)
+	p := grossUpParams{region: region, tolerance: defaultGrossUpTolerance}
+
+	filer, filerErrorStr := readFilerParams(r, false)
+	errorStr = errorStr + filerErrorStr
+	filer.Resident = true
+
+	// exactly one of the net income or net pay must be given
+	netIncomeStr := r.URL.Query().Get("netIncome")
+	netPayStr := r.URL.Query().Get("netPay")
+	if netIncomeStr == "" && netPayStr == "" {
+		errorStr = errorStr + "\nA net income or a net pay per paycheck must be provided."
+	} else if netIncomeStr != "" && netPayStr != "" {
+		errorStr = errorStr + "\nOnly one of a net income or a net pay per paycheck can be provided."
+	} else if netIncomeStr != "" {
+		var err error
+		p.netIncome, err = strconv.Atoi(netIncomeStr)
+		if err != nil || p.netIncome <= 0 {
+			errorStr = errorStr + "\nThe provided net income must be a positive integer."
+		}
+	} else {
+		var err error
+		p.perPaycheck = true
+		p.netPay, err = strconv.ParseFloat(netPayStr, 64)
+		if err != nil || p.netPay <= 0 {
+			errorStr = errorStr + "\nThe provided net pay must be a positive number."
+		}
+		if filer.Employment_type == model.SelfEmployed {
+			errorStr = errorStr + "\nPaychecks can only be estimated for employees."
+		}
+	}
+
+	// the tolerance is optional
+	if toleranceStr := r.URL.Query().Get("tolerance"); toleranceStr != "" {
+		var err error
+		p.tolerance, err = strconv.Atoi(toleranceStr)
+		if err != nil || p.tolerance < 0 {
+			errorStr = errorStr + "\nThe provided tolerance must be a non-negative integer."
+		}
+	}
+
+	return p, filer, errorStr
+}
+
+// a county or state of a compare request, and the tax locale of a county the taxes are compared in
+type regionParams struct {
+	regionType  string
+	id          int
+	name        string
+	localeId    int
+	localeGiven bool
+}
+
+// reads the region identified by the type, name, and id parameters with the given prefix, a name is used over an id.
+// The locale id is optional and only read for counties
+func getRegionParams(prefix string, r *http.Request) (regionParams, string) {
+	errorStr := ""
+	p := regionParams{
+		regionType: strings.TrimSpace(strings.ToLower(r.URL.Query().Get(prefix + "Type"))),
+		name:       r.URL.Query().Get(prefix + "Name"),
+	}
+	if p.regionType != model.CountyRegion && p.regionType != model.StateRegion {
+		errorStr = errorStr + fmt.Sprintf("\nThe provided %s type must indicate 'county' or 'state'.", prefix)
+	}
+
+	idStr := r.URL.Query().Get(prefix + "Id")
+	if p.name == "" && idStr == "" {
+		errorStr = errorStr + fmt.Sprintf("\nA %s name or id must be provided.", prefix)
+	} else if p.name == "" {
+		var err error
+		p.id, err = strconv.Atoi(idStr)
+		if err != nil {
+			errorStr = errorStr + fmt.Sprintf("\nThe provided %s id must be an integer.", prefix)
+		}
+	}
+
+	if localeIdStr := r.URL.Query().Get(prefix + "LocaleId"); localeIdStr != "" {
+		var err error
+		p.localeGiven = true
+		p.localeId, err = strconv.Atoi(localeIdStr)
+		if err != nil {
+			errorStr = errorStr + fmt.Sprintf("\nThe provided %s locale id must be an integer.", prefix)
+		}
+		if p.regionType == model.StateRegion {
+			errorStr = errorStr + fmt.Sprintf("\nA %s locale id can only be provided for a county.", prefix)
+		}
+	}
+
+	return p, errorStr
+}
+
+// reads the county identified by the name and id parameters with the given prefix, a name is used over an id. Returns
+// the id, the name, and whether either was provided. The label describes the county in error messages
+func getCountyIdentifier(prefix, label string, r *http.Request) (int, string, bool, string) {
+	idStr := r.URL.Query().Get(prefix + "Id")
+	name := r.URL.Query().Get(prefix + "Name")
+	if name != "" || idStr == "" {
+		return 0, name, name != "", ""
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, "", true, fmt.Sprintf("\nThe provided %s county id must be an integer.", label)
+	}
+
+	return id, "", true, ""
+}
+
+// explain is optional, defaults to false
+func getExplainParam(r *http.Request) (bool, string) {
+	explainStr := r.URL.Query().Get("explain")
+	if explainStr == "" {
+		return false, ""
+	}
+
+	explain, err := strconv.ParseBool(explainStr)
+	if err != nil {
+		return false, "\nThe provided explain flag must be interpretable as a boolean"
+	}
+
+	return explain, ""
+}
+
+// the tax year is optional, 0 selects the default year
+func getYearParam(r *http.Request) (int, string) {
+	yearStr := r.URL.Query().Get("year")
+	if yearStr == "" {
+		return 0, ""
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year <= 0 {
+		return 0, "\nThe provided year must be a positive integer."
+	}
+
+	return year, ""
+}
+
+// validates the tax filer variables used to estimate taxes for a region
+func getFilerParams(r *http.Request) (model.FilerProfile, string) {
+	return readFilerParams(r, true)
+}
+
+// validates the tax filer variables, reading the income only when it is not solved for
+func readFilerParams(r *http.Request, readIncome bool) (model.FilerProfile, string) {
+	// concat issues with parametes as encountered for the response
+	errorStr := ""
+
+	// read in the expected parameters as strings
+	fsStr := r.URL.Query().Get("filingStatus")
+	depStr := r.URL.Query().Get("dependents")
+	incomeStr := r.URL.Query().Get("income")
+	excludePayrollStr := r.URL.Query().Get("excludePayroll")
+	employmentTypeStr := r.URL.Query().Get("employmentType")
+	childrenStr := r.URL.Query().Get("qualifyingChildren")
+
+	var filer model.FilerProfile
+
+	// error
+	var err error
+
+	// fs must be valid
+	filer.Filing_status, err = model.ToFilingStatus(fsStr)
+	if err != nil {
+		errorStr = errorStr + "\nThe provided filing status must indicate 'S', 'H', 'M', 'MFS', or 'QSS'."
+	}
+
 	// the dep must be an integer
-	dep, err = strconv.Atoi(depStr)
+	filer.Dependents, err = strconv.Atoi(depStr)
 	if err != nil {
 		errorStr = errorStr + "\nThe provided number of dependents must be an integer."
 	}
 
+	// qualifying children are optional, all dependents are treated as qualifying children by default
+	filer.Qualifying_children = filer.Dependents
+	if childrenStr != "" {
+		filer.Qualifying_children, err = strconv.Atoi(childrenStr)
+		if err != nil || filer.Qualifying_children < 0 || filer.Qualifying_children > filer.Dependents {
+			errorStr = errorStr + "\nThe provided number of qualifying children must be an integer no greater than the number of dependents."
+		}
+	}
+
 	// the income must be an integer
-	income, err = strconv.Atoi(incomeStr)
+	if readIncome {
+		filer.Income, err = strconv.Atoi(incomeStr)
+		if err != nil {
+			errorStr = errorStr + "\nThe provided income must be an integer."
+		}
+	}
+
+	// investment income, pre-tax contributions, and itemized expenses are optional, each amount defaults to 0
+	amountParams := []struct {
+		name  string
+		value *int
+	}{
+		{"interest", &filer.Interest},
+		{"shortTermGains", &filer.Short_term_gains},
+		{"longTermGains", &filer.Long_term_gains},
+		{"qualifiedDividends", &filer.Qualified_dividends},
+		{"retirementContributions", &filer.Retirement_contributions},
+		{"hsaContributions", &filer.Hsa_contributions},
+		{"section125Contributions", &filer.Section_125_contributions},
+		{"iraContributions", &filer.Ira_contributions},
+		{"mortgageInterest", &filer.Mortgage_interest},
+		{"charitableGifts", &filer.Charitable_gifts},
+		{"medicalExpenses", &filer.Medical_expenses},
+		{"propertyTax", &filer.Property_tax},
+		{"annualSpending", &filer.Annual_spending},
+		{"homeValue", &filer.Home_value},
+	}
+	for _, p := range amountParams {
+		if s := r.URL.Query().Get(p.name); s != "" {
+			*p.value, err = strconv.Atoi(s)
+			if err != nil || *p.value < 0 {
+				errorStr = errorStr + "\nThe provided " + p.name + " must be a non-negative integer."
+			}
+		}
+	}
+
+	// the hsa family coverage flag is optional, defaults to self-only coverage
+	if hsaFamilyStr := r.URL.Query().Get("hsaFamily"); hsaFamilyStr != "" {
+		filer.Hsa_family, err = strconv.ParseBool(hsaFamilyStr)
+		if err != nil {
+			errorStr = errorStr + "\nThe provided hsa family flag must be interpretable as a boolean"
+		}
+	}
+
+	// deducting the estimated property tax is optional, defaults to false
+	if deductPropertyTaxStr := r.URL.Query().Get("deductPropertyTax"); deductPropertyTaxStr != "" {
+		filer.Deduct_property_tax, err = strconv.ParseBool(deductPropertyTaxStr)
+		if err != nil {
+			errorStr = errorStr + "\nThe provided deduct property tax flag must be interpretable as a boolean"
+		}
+	}
+
+	// employment type is optional, defaults to an employee
+	filer.Employment_type, err = model.ToEmploymentType(employmentTypeStr)
 	if err != nil {
-		errorStr = errorStr + "\nThe provided income must be an integer."
+		errorStr = errorStr + "\nThe provided employment type must indicate 'employee' or 'self'."
+	}
+
+	// pay frequency is optional, defaults to biweekly
+	filer.Pay_frequency, err = model.ToPayFrequency(r.URL.Query().Get("payFrequency"))
+	if err != nil {
+		errorStr = errorStr + "\nThe provided pay frequency must indicate 'weekly', 'biweekly', 'semimonthly', or 'monthly'."
+	}
+
+	// the tax year is optional, defaults to the current year
+	var yearErrorStr string
+	filer.Tax_year, yearErrorStr = getYearParam(r)
+	errorStr = errorStr + yearErrorStr
+
+	// excluding payroll taxes is optional, defaults to false
+	if excludePayrollStr != "" {
+		filer.Exclude_payroll, err = strconv.ParseBool(excludePayrollStr)
+		if err != nil {
+			errorStr = errorStr + "\nThe provided exclude payroll flag must be interpretable as a boolean"
+		}
 	}
 
-	return id, name, fs, res, dep, income, errorStr
+	return filer, errorStr
 }
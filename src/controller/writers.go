@@ -1,8 +1,8 @@
 package controller
 
 import (
-	"net/http"
 	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -23,7 +23,7 @@ func writeResponse(w http.ResponseWriter, isGet bool, statusCode int, b []byte)
 
 // helper method that will return the name if not empty, else will return the given int as a string
 func nameOrId(name string, id int) string {
-	if name != ""{
+	if name != "" {
 		return name
 	}
 
@@ -72,4 +72,4 @@ func write200Response(w http.ResponseWriter, isGet bool, start time.Time, b []by
 	writeResponse(w, isGet, http.StatusOK, b)
 	elapsed := time.Since(start)
 	logger.Info("Returned 200 response in %s", elapsed)
-}
\ No newline at end of file
+}
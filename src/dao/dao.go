@@ -9,12 +9,24 @@ import (
 type DaoInterface interface {
 	// state data access methods
 	GetStateCensusData() ([][]interface{}, *apperrors.AppError)
-	GetStateTax() ([][]interface{}, *apperrors.AppError)
+	GetStateTax(year int) ([][]interface{}, *apperrors.AppError)
+	// pairs of resident and work states with a reciprocity agreement
+	GetStateReciprocity() ([][]interface{}, *apperrors.AppError)
+	// sales tax rates of the states, counties, and tax locales
+	GetSalesTaxRates() ([][]interface{}, *apperrors.AppError)
 	// county data access method (pull both tax and census information at the same time)
 	GetCountyDataById(county_id int) ([][]interface{}, *apperrors.AppError)
 	GetCountyDataByName(county_name string) ([][]interface{}, *apperrors.AppError)
+	// the same data for every county, ordered by county, to rank counties by computed metrics
+	GetAllCountyData() ([][]interface{}, *apperrors.AppError)
 	// to pull top listing for a metric for counties
 	GetCountyList(metric string, n int, desc bool) ([][]interface{}, *apperrors.AppError)
 	// federal tax data access
-	GetFederalTaxData() ([][]interface{}, *apperrors.AppError)
+	GetFederalTaxData(year int) ([][]interface{}, *apperrors.AppError)
+	// payroll, credit, and other federal parameters of a tax year, one row with the thresholds of every filing status
+	GetFederalParameters(year int) ([][]interface{}, *apperrors.AppError)
+	// earned income credit schedules of a tax year, ordered by number of children
+	GetEarnedIncomeCreditSchedules(year int) ([][]interface{}, *apperrors.AppError)
+	// tax years with brackets loaded
+	GetTaxYears() ([][]interface{}, *apperrors.AppError)
 }
@@ -4,16 +4,15 @@ package dao
 
 import (
 	"database/sql"
+	"embed"
 	"fmt"
 	"strconv"
 	"strings"
-	"embed"
 
 	_ "github.com/lib/pq"
 
 	"github.com/Matthew-Curry/re-region-api/src/apperrors"
 	"github.com/Matthew-Curry/re-region-api/src/logging"
-
 )
 
 //go:embed sql
@@ -24,19 +23,31 @@ const (
 	GET_METRIC_SET      string = "GET_METRIC_SET"
 	COUNTY_DATA_BY_ID   string = "COUNTY_DATA_BY_ID"
 	COUNTY_DATA_BY_NAME string = "COUNTY_DATA_BY_NAME"
+	ALL_COUNTY_DATA     string = "ALL_COUNTY_DATA"
 	FEDERAL_TAX_DATA    string = "FEDERAL_TAX_DATA"
+	FEDERAL_PARAMETERS  string = "FEDERAL_PARAMETERS"
+	EITC_SCHEDULES      string = "EITC_SCHEDULES"
 	STATE_CENSUS_DATA   string = "STATE_CENSUS_DATA"
 	STATE_TAX_DATA      string = "STATE_TAX_DATA"
+	STATE_RECIPROCITY   string = "STATE_RECIPROCITY"
+	SALES_TAX_RATES     string = "SALES_TAX_RATES"
 	COUNTY_LIST_DATA    string = "COUNTY_LIST_DATA"
+	TAX_YEARS           string = "TAX_YEARS"
 
 	// sql queries
 	GET_METRIC_SET_QUERY      string = "sql/metric_set.sql"
 	COUNTY_DATA_BY_ID_QUERY   string = "sql/county_data_by_id.sql"
 	COUNTY_DATA_BY_NAME_QUERY string = "sql/county_data_by_name.sql"
+	ALL_COUNTY_DATA_QUERY     string = "sql/county_data_all.sql"
 	FEDERAL_TAX_DATA_QUERY    string = "sql/federal_tax_data.sql"
+	FEDERAL_PARAMETERS_QUERY  string = "sql/federal_parameters.sql"
+	EITC_SCHEDULES_QUERY      string = "sql/eitc_schedules.sql"
 	STATE_CENSUS_DATA_QUERY   string = "sql/state_census_data.sql"
 	STATE_TAX_DATA_QUERY      string = "sql/state_tax_data.sql"
+	STATE_RECIPROCITY_QUERY   string = "sql/state_reciprocity.sql"
+	SALES_TAX_RATES_QUERY     string = "sql/sales_tax_rates.sql"
 	COUNTY_LIST_DATA_QUERY    string = "sql/county_list.sql"
+	TAX_YEARS_QUERY           string = "sql/tax_years.sql"
 )
 
 var logger, _ = logging.GetLogger("file.log")
@@ -57,10 +68,16 @@ func GetPostgresDao(user, password, dbname, host, port string) (DaoInterface, *a
 		"GET_METRIC_SET":      GET_METRIC_SET_QUERY,
 		"COUNTY_DATA_BY_ID":   COUNTY_DATA_BY_ID_QUERY,
 		"COUNTY_DATA_BY_NAME": COUNTY_DATA_BY_NAME_QUERY,
+		"ALL_COUNTY_DATA":     ALL_COUNTY_DATA_QUERY,
 		"FEDERAL_TAX_DATA":    FEDERAL_TAX_DATA_QUERY,
+		"FEDERAL_PARAMETERS":  FEDERAL_PARAMETERS_QUERY,
+		"EITC_SCHEDULES":      EITC_SCHEDULES_QUERY,
 		"STATE_CENSUS_DATA":   STATE_CENSUS_DATA_QUERY,
 		"STATE_TAX_DATA":      STATE_TAX_DATA_QUERY,
+		"STATE_RECIPROCITY":   STATE_RECIPROCITY_QUERY,
+		"SALES_TAX_RATES":     SALES_TAX_RATES_QUERY,
 		"COUNTY_LIST_DATA":    COUNTY_LIST_DATA_QUERY,
+		"TAX_YEARS":           TAX_YEARS_QUERY,
 	}
 
 	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s "+
@@ -157,21 +174,63 @@ func (d *DaoImpl) GetCountyList(metric string, n int, desc bool) ([][]interface{
 	return res, nil
 }
 
-func (d *DaoImpl) GetStateTax() ([][]interface{}, *apperrors.AppError) {
+func (d *DaoImpl) GetStateTax(year int) ([][]interface{}, *apperrors.AppError) {
 	query, err := d.readSQLFileAsString(STATE_TAX_DATA)
 
 	if err != nil {
 		return nil, err
 	}
-	logger.Info("Executing State tax query")
-	res, err := d.getRowsFromQuery(query)
+	logger.Info("Executing State tax query for %v", year)
+	res, err := d.getRowsFromQuery(query, year)
 	if err != nil {
+		// a year without state brackets is skipped by the state service
+		if err.IsKind(apperrors.DataNotFound) {
+			return [][]interface{}{}, nil
+		}
 		return nil, apperrors.UnableToGetStateTax(err)
 	}
 
 	return res, nil
 }
 
+func (d *DaoImpl) GetStateReciprocity() ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(STATE_RECIPROCITY)
+
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Executing State reciprocity query")
+	res, err := d.getRowsFromQuery(query)
+	if err != nil {
+		// no agreements is a valid state of the table
+		if err.IsKind(apperrors.DataNotFound) {
+			return [][]interface{}{}, nil
+		}
+		return nil, apperrors.UnableToGetStateReciprocity(err)
+	}
+
+	return res, nil
+}
+
+func (d *DaoImpl) GetSalesTaxRates() ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(SALES_TAX_RATES)
+
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Executing Sales tax rates query")
+	res, err := d.getRowsFromQuery(query)
+	if err != nil {
+		// areas without sales tax rates have no sales tax
+		if err.IsKind(apperrors.DataNotFound) {
+			return [][]interface{}{}, nil
+		}
+		return nil, apperrors.UnableToGetSalesTaxRates(err)
+	}
+
+	return res, nil
+}
+
 func (d *DaoImpl) GetCountyDataByName(county_name string) ([][]interface{}, *apperrors.AppError) {
 	query, err := d.readSQLFileAsString(COUNTY_DATA_BY_NAME)
 
@@ -216,15 +275,31 @@ func (d *DaoImpl) GetCountyDataById(county_id int) ([][]interface{}, *apperrors.
 	return res, nil
 }
 
-func (d *DaoImpl) GetFederalTaxData() ([][]interface{}, *apperrors.AppError) {
-	query, err := d.readSQLFileAsString(FEDERAL_TAX_DATA)
+func (d *DaoImpl) GetAllCountyData() ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(ALL_COUNTY_DATA)
 
 	if err != nil {
 		return nil, err
 	}
+	logger.Info("Executing all County data query")
 
-	logger.Info("Executing Federal tax query")
 	res, err := d.getRowsFromQuery(query)
+	if err != nil {
+		return nil, apperrors.UnableToGetAllCountyData(err)
+	}
+
+	return res, nil
+}
+
+func (d *DaoImpl) GetFederalTaxData(year int) ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(FEDERAL_TAX_DATA)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Executing Federal tax query for %v", year)
+	res, err := d.getRowsFromQuery(query, year)
 	if err != nil {
 		return nil, apperrors.UnableToGetFederalTax(err)
 	}
@@ -232,6 +307,62 @@ func (d *DaoImpl) GetFederalTaxData() ([][]interface{}, *apperrors.AppError) {
 	return res, nil
 }
 
+func (d *DaoImpl) GetFederalParameters(year int) ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(FEDERAL_PARAMETERS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Executing Federal parameters query for %v", year)
+	res, err := d.getRowsFromQuery(query, year)
+	if err != nil {
+		// a year without published parameters is not loaded by the federal service
+		if err.IsKind(apperrors.DataNotFound) {
+			return [][]interface{}{}, nil
+		}
+		return nil, apperrors.UnableToGetFederalParameters(err)
+	}
+
+	return res, nil
+}
+
+func (d *DaoImpl) GetEarnedIncomeCreditSchedules(year int) ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(EITC_SCHEDULES)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Executing Earned income credit schedules query for %v", year)
+	res, err := d.getRowsFromQuery(query, year)
+	if err != nil {
+		// a year without published schedules is not loaded by the federal service
+		if err.IsKind(apperrors.DataNotFound) {
+			return [][]interface{}{}, nil
+		}
+		return nil, apperrors.UnableToGetEarnedIncomeCreditSchedules(err)
+	}
+
+	return res, nil
+}
+
+func (d *DaoImpl) GetTaxYears() ([][]interface{}, *apperrors.AppError) {
+	query, err := d.readSQLFileAsString(TAX_YEARS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Executing Tax years query")
+	res, err := d.getRowsFromQuery(query)
+	if err != nil {
+		return nil, apperrors.UnableToGetTaxYears(err)
+	}
+
+	return res, nil
+}
+
 // helper method to read given sql type in for a given query identifier
 func (d *DaoImpl) readSQLFileAsString(queryId string) (string, *apperrors.AppError) {
 	logger.Info("Reading in SQL for %s", queryId)
@@ -0,0 +1,7 @@
+-- pairs of resident and work states with a reciprocity agreement, the work state does not tax the wages of residents
+-- of the resident state. An empty table is valid, commutes between states are then taxed by both states
+CREATE TABLE IF NOT EXISTS state_reciprocity (
+    resident_state_id INTEGER NOT NULL REFERENCES states (state_id),
+    work_state_id INTEGER NOT NULL REFERENCES states (state_id),
+    PRIMARY KEY (resident_state_id, work_state_id)
+);
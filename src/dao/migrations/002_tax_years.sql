@@ -0,0 +1,71 @@
+-- versions the federal and state tax tables by tax year and adds the filing status and state columns read by
+-- federal_tax_data.sql, state_tax_data.sql and tax_years.sql. Rows loaded before the tables were versioned hold the
+-- 2022 schedules
+BEGIN;
+
+-- federal brackets and standard deductions for every filing status, by tax year
+ALTER TABLE federal_brackets ADD COLUMN IF NOT EXISTS tax_year INTEGER NOT NULL DEFAULT 2022;
+ALTER TABLE federal_brackets ALTER COLUMN tax_year DROP DEFAULT;
+ALTER TABLE federal_brackets ADD COLUMN IF NOT EXISTS separate_bracket INTEGER;
+ALTER TABLE federal_brackets ADD COLUMN IF NOT EXISTS surviving_bracket INTEGER;
+-- separate filers use half of the married brackets and surviving spouses the married brackets
+UPDATE federal_brackets SET separate_bracket = married_bracket / 2 WHERE separate_bracket IS NULL;
+UPDATE federal_brackets SET surviving_bracket = married_bracket WHERE surviving_bracket IS NULL;
+ALTER TABLE federal_brackets ALTER COLUMN separate_bracket SET NOT NULL;
+ALTER TABLE federal_brackets ALTER COLUMN surviving_bracket SET NOT NULL;
+
+ALTER TABLE federal_deductions ADD COLUMN IF NOT EXISTS tax_year INTEGER NOT NULL DEFAULT 2022;
+ALTER TABLE federal_deductions ALTER COLUMN tax_year DROP DEFAULT;
+ALTER TABLE federal_deductions ADD COLUMN IF NOT EXISTS separate_deduction INTEGER;
+ALTER TABLE federal_deductions ADD COLUMN IF NOT EXISTS surviving_deduction INTEGER;
+UPDATE federal_deductions SET separate_deduction = single_deduction WHERE separate_deduction IS NULL;
+UPDATE federal_deductions SET surviving_deduction = married_deduction WHERE surviving_deduction IS NULL;
+ALTER TABLE federal_deductions ALTER COLUMN separate_deduction SET NOT NULL;
+ALTER TABLE federal_deductions ALTER COLUMN surviving_deduction SET NOT NULL;
+
+-- state brackets by tax year, states without head of household brackets leave them null and use the single brackets
+ALTER TABLE state_brackets ADD COLUMN IF NOT EXISTS tax_year INTEGER NOT NULL DEFAULT 2022;
+ALTER TABLE state_brackets ALTER COLUMN tax_year DROP DEFAULT;
+ALTER TABLE state_brackets ADD COLUMN IF NOT EXISTS head_rate NUMERIC;
+ALTER TABLE state_brackets ADD COLUMN IF NOT EXISTS head_bracket INTEGER;
+
+-- state deductions and exemptions by tax year, null head of household columns fall back to the single columns
+CREATE TABLE IF NOT EXISTS state_deductions (
+    state_id INTEGER NOT NULL REFERENCES states (state_id),
+    tax_year INTEGER NOT NULL,
+    single_deduction INTEGER,
+    married_deduction INTEGER,
+    head_deduction INTEGER,
+    single_exemption INTEGER,
+    married_exemption INTEGER,
+    head_exemption INTEGER,
+    dependent_exemption INTEGER,
+    PRIMARY KEY (state_id, tax_year)
+);
+-- copies the unversioned deductions and exemptions on the states table to each year with brackets
+INSERT INTO state_deductions (state_id, tax_year, single_deduction, married_deduction, single_exemption, married_exemption, dependent_exemption)
+SELECT DISTINCT
+    states.state_id,
+    state_brackets.tax_year,
+    states.single_deduction,
+    states.married_deduction,
+    states.single_exemption,
+    states.married_exemption,
+    states.dependent_exemption
+FROM states INNER JOIN state_brackets ON states.state_id = state_brackets.state_id
+ON CONFLICT (state_id, tax_year) DO NOTHING;
+
+-- state credits, exclusions and deductibility, null columns take the defaults in state_tax_data.sql
+ALTER TABLE states ADD COLUMN IF NOT EXISTS eitc_percent NUMERIC;
+ALTER TABLE states ADD COLUMN IF NOT EXISTS capital_gains_exclusion NUMERIC;
+ALTER TABLE states ADD COLUMN IF NOT EXISTS retirement_deductible BOOLEAN;
+ALTER TABLE states ADD COLUMN IF NOT EXISTS hsa_deductible BOOLEAN;
+ALTER TABLE states ADD COLUMN IF NOT EXISTS indexed BOOLEAN;
+
+-- local tax treatment of self-employment income and pre-tax contributions, null columns take the defaults in
+-- county_data_*.sql
+ALTER TABLE tax_locale ADD COLUMN IF NOT EXISTS self_employment_taxed BOOLEAN;
+ALTER TABLE tax_locale ADD COLUMN IF NOT EXISTS retirement_deductible BOOLEAN;
+ALTER TABLE tax_locale ADD COLUMN IF NOT EXISTS hsa_deductible BOOLEAN;
+
+COMMIT;
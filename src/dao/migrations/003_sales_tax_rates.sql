@@ -0,0 +1,11 @@
+-- sales tax rates read by sales_tax_rates.sql, each row holds the rate of one area and the rates of the state, county,
+-- and tax locale of a filer are summed. A row without a county holds the state rate, a row with a county and without a
+-- tax locale the county rate, and a row with both the tax locale rate
+CREATE TABLE IF NOT EXISTS sales_tax_rates (
+    sales_tax_rate_id SERIAL PRIMARY KEY,
+    state_id INTEGER NOT NULL REFERENCES states (state_id),
+    county_id INTEGER REFERENCES county (county_id),
+    tax_locale_id INTEGER REFERENCES tax_locale (tax_locale_id),
+    rate NUMERIC NOT NULL,
+    UNIQUE (state_id, county_id, tax_locale_id)
+);
@@ -0,0 +1,4 @@
+-- effective property tax rate and median home value of each county, read by county_data_*.sql. Counties without a
+-- rate have no property tax estimate, and without a median home value no estimate for filers without a home value
+ALTER TABLE county ADD COLUMN IF NOT EXISTS property_tax_rate NUMERIC;
+ALTER TABLE county ADD COLUMN IF NOT EXISTS median_home_value INTEGER;
@@ -0,0 +1,99 @@
+-- federal payroll, credit, investment income, contribution, itemized deduction, and alternative minimum tax parameters
+-- by tax year, read by federal_parameters.sql and eitc_schedules.sql. A tax year is only served when it has a row in
+-- each table, see tax_years.sql. Seeded with the published 2021 and 2022 parameters
+BEGIN;
+
+-- parameters that do not vary by filing status
+CREATE TABLE IF NOT EXISTS federal_parameters (
+    tax_year INTEGER PRIMARY KEY,
+    social_security_rate NUMERIC NOT NULL,
+    social_security_wage_base INTEGER NOT NULL,
+    medicare_rate NUMERIC NOT NULL,
+    additional_medicare_rate NUMERIC NOT NULL,
+    child_credit INTEGER NOT NULL,
+    child_refundable_credit INTEGER NOT NULL,
+    child_refundable_rate NUMERIC NOT NULL,
+    child_refundable_income_threshold INTEGER NOT NULL,
+    other_dependent_credit INTEGER NOT NULL,
+    dependent_credit_phase_out_amount INTEGER NOT NULL,
+    dependent_credit_phase_out_step INTEGER NOT NULL,
+    single_dependent_credit_phase_out_threshold INTEGER NOT NULL,
+    married_dependent_credit_phase_out_threshold INTEGER NOT NULL,
+    eitc_investment_income_limit INTEGER NOT NULL,
+    investment_mid_rate NUMERIC NOT NULL,
+    investment_top_rate NUMERIC NOT NULL,
+    net_investment_income_rate NUMERIC NOT NULL,
+    retirement_limit INTEGER NOT NULL,
+    ira_limit INTEGER NOT NULL,
+    hsa_self_limit INTEGER NOT NULL,
+    hsa_family_limit INTEGER NOT NULL,
+    salt_cap INTEGER NOT NULL,
+    separate_salt_cap INTEGER NOT NULL,
+    medical_floor_rate NUMERIC NOT NULL,
+    charitable_limit_rate NUMERIC NOT NULL,
+    amt_low_rate NUMERIC NOT NULL,
+    amt_high_rate NUMERIC NOT NULL,
+    amt_phase_out_rate NUMERIC NOT NULL
+);
+
+-- thresholds set by filing status, one row for each of the s, m, h, mfs, and qss statuses
+CREATE TABLE IF NOT EXISTS federal_status_parameters (
+    tax_year INTEGER NOT NULL REFERENCES federal_parameters (tax_year),
+    filing_status VARCHAR(3) NOT NULL CHECK (filing_status IN ('s', 'm', 'h', 'mfs', 'qss')),
+    additional_medicare_threshold INTEGER NOT NULL,
+    zero_rate_threshold INTEGER NOT NULL,
+    mid_rate_threshold INTEGER NOT NULL,
+    net_investment_income_threshold INTEGER NOT NULL,
+    amt_exemption INTEGER NOT NULL,
+    amt_phase_out_threshold INTEGER NOT NULL,
+    amt_rate_threshold INTEGER NOT NULL,
+    PRIMARY KEY (tax_year, filing_status)
+);
+
+-- earned income credit schedules by number of qualifying children, the last schedule applies to any more children
+CREATE TABLE IF NOT EXISTS federal_eitc_schedules (
+    tax_year INTEGER NOT NULL REFERENCES federal_parameters (tax_year),
+    children INTEGER NOT NULL,
+    credit_rate NUMERIC NOT NULL,
+    max_credit INTEGER NOT NULL,
+    phase_out_rate NUMERIC NOT NULL,
+    single_phase_out_threshold INTEGER NOT NULL,
+    married_phase_out_threshold INTEGER NOT NULL,
+    PRIMARY KEY (tax_year, children)
+);
+
+-- the expanded 2021 child credit for children 6 and over, fully refundable. The lower phase-out of the expansion and
+-- the larger credit for children under 6 are not modeled. Cash gifts to public charities were deductible up to all of
+-- adjusted gross income in 2021
+INSERT INTO federal_parameters VALUES
+    (2021, 0.062, 142800, 0.0145, 0.009, 3000, 3000, 1, 0, 500, 50, 1000, 200000, 400000, 10000, 0.15, 0.20, 0.038,
+        19500, 6000, 3600, 7200, 10000, 5000, 0.075, 1, 0.26, 0.28, 0.25),
+    (2022, 0.062, 147000, 0.0145, 0.009, 2000, 1500, 0.15, 2500, 500, 50, 1000, 200000, 400000, 10300, 0.15, 0.20, 0.038,
+        20500, 6000, 3650, 7300, 10000, 5000, 0.075, 0.6, 0.26, 0.28, 0.25)
+ON CONFLICT (tax_year) DO NOTHING;
+
+INSERT INTO federal_status_parameters VALUES
+    (2021, 's', 200000, 40400, 445850, 200000, 73600, 523600, 199900),
+    (2021, 'm', 250000, 80800, 501600, 250000, 114600, 1047200, 199900),
+    (2021, 'h', 200000, 54100, 473750, 200000, 73600, 523600, 199900),
+    (2021, 'mfs', 125000, 40400, 250800, 125000, 57300, 523600, 99950),
+    (2021, 'qss', 200000, 80800, 501600, 250000, 114600, 1047200, 199900),
+    (2022, 's', 200000, 41675, 459750, 200000, 75900, 539900, 206100),
+    (2022, 'm', 250000, 83350, 517200, 250000, 118100, 1079800, 206100),
+    (2022, 'h', 200000, 55800, 488500, 200000, 75900, 539900, 206100),
+    (2022, 'mfs', 125000, 41675, 258600, 125000, 59050, 539900, 103050),
+    (2022, 'qss', 200000, 83350, 517200, 250000, 118100, 1079800, 206100)
+ON CONFLICT (tax_year, filing_status) DO NOTHING;
+
+INSERT INTO federal_eitc_schedules VALUES
+    (2021, 0, 0.153, 1502, 0.153, 11610, 17560),
+    (2021, 1, 0.34, 3618, 0.1598, 19520, 25470),
+    (2021, 2, 0.40, 5980, 0.2106, 19520, 25470),
+    (2021, 3, 0.45, 6728, 0.2106, 19520, 25470),
+    (2022, 0, 0.0765, 560, 0.0765, 9160, 15290),
+    (2022, 1, 0.34, 3733, 0.1598, 20130, 26260),
+    (2022, 2, 0.40, 6164, 0.2106, 20130, 26260),
+    (2022, 3, 0.45, 6935, 0.2106, 20130, 26260)
+ON CONFLICT (tax_year, children) DO NOTHING;
+
+COMMIT;
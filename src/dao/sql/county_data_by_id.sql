@@ -8,6 +8,8 @@ SELECT
     county.median_income,
     county.average_rent,
     county.commute,
+    COALESCE(county.property_tax_rate, 0),
+    COALESCE(county.median_home_value, 0),
     COALESCE(tax_locale.tax_locale_id, 0),
     COALESCE(tax_locale.tax_locale, ''),
     COALESCE(tax_locale.resident_desc, ''),
@@ -21,6 +23,9 @@ SELECT
     COALESCE(tax_locale.nonresident_month_fee, 0),
     COALESCE(tax_locale.nonresident_year_fee, 0),
     COALESCE(tax_locale.nonresident_pay_period_fee, 0),
-    COALESCE(tax_locale.nonresident_state_rate, 0)
+    COALESCE(tax_locale.nonresident_state_rate, 0),
+    COALESCE(tax_locale.self_employment_taxed, true),
+    COALESCE(tax_locale.retirement_deductible, true),
+    COALESCE(tax_locale.hsa_deductible, true)
 FROM county LEFT JOIN tax_locale ON county.county_id = tax_locale.county_id
 WHERE county.county_id = ? AND county.county_id != 32767;
\ No newline at end of file
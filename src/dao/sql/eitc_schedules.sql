@@ -0,0 +1,10 @@
+SELECT 
+    federal_eitc_schedules.children,
+    federal_eitc_schedules.credit_rate,
+    federal_eitc_schedules.max_credit,
+    federal_eitc_schedules.phase_out_rate,
+    federal_eitc_schedules.single_phase_out_threshold,
+    federal_eitc_schedules.married_phase_out_threshold
+FROM federal_eitc_schedules
+WHERE federal_eitc_schedules.tax_year = ?
+ORDER BY federal_eitc_schedules.children;
\ No newline at end of file
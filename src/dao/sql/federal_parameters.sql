@@ -0,0 +1,72 @@
+SELECT 
+    federal_parameters.social_security_rate,
+    federal_parameters.social_security_wage_base,
+    federal_parameters.medicare_rate,
+    federal_parameters.additional_medicare_rate,
+    federal_parameters.child_credit,
+    federal_parameters.child_refundable_credit,
+    federal_parameters.child_refundable_rate,
+    federal_parameters.child_refundable_income_threshold,
+    federal_parameters.other_dependent_credit,
+    federal_parameters.dependent_credit_phase_out_amount,
+    federal_parameters.dependent_credit_phase_out_step,
+    federal_parameters.single_dependent_credit_phase_out_threshold,
+    federal_parameters.married_dependent_credit_phase_out_threshold,
+    federal_parameters.eitc_investment_income_limit,
+    federal_parameters.investment_mid_rate,
+    federal_parameters.investment_top_rate,
+    federal_parameters.net_investment_income_rate,
+    federal_parameters.retirement_limit,
+    federal_parameters.ira_limit,
+    federal_parameters.hsa_self_limit,
+    federal_parameters.hsa_family_limit,
+    federal_parameters.salt_cap,
+    federal_parameters.separate_salt_cap,
+    federal_parameters.medical_floor_rate,
+    federal_parameters.charitable_limit_rate,
+    federal_parameters.amt_low_rate,
+    federal_parameters.amt_high_rate,
+    federal_parameters.amt_phase_out_rate,
+    single_status.additional_medicare_threshold,
+    married_status.additional_medicare_threshold,
+    head_status.additional_medicare_threshold,
+    separate_status.additional_medicare_threshold,
+    surviving_status.additional_medicare_threshold,
+    single_status.zero_rate_threshold,
+    married_status.zero_rate_threshold,
+    head_status.zero_rate_threshold,
+    separate_status.zero_rate_threshold,
+    surviving_status.zero_rate_threshold,
+    single_status.mid_rate_threshold,
+    married_status.mid_rate_threshold,
+    head_status.mid_rate_threshold,
+    separate_status.mid_rate_threshold,
+    surviving_status.mid_rate_threshold,
+    single_status.net_investment_income_threshold,
+    married_status.net_investment_income_threshold,
+    head_status.net_investment_income_threshold,
+    separate_status.net_investment_income_threshold,
+    surviving_status.net_investment_income_threshold,
+    single_status.amt_exemption,
+    married_status.amt_exemption,
+    head_status.amt_exemption,
+    separate_status.amt_exemption,
+    surviving_status.amt_exemption,
+    single_status.amt_phase_out_threshold,
+    married_status.amt_phase_out_threshold,
+    head_status.amt_phase_out_threshold,
+    separate_status.amt_phase_out_threshold,
+    surviving_status.amt_phase_out_threshold,
+    single_status.amt_rate_threshold,
+    married_status.amt_rate_threshold,
+    head_status.amt_rate_threshold,
+    separate_status.amt_rate_threshold,
+    surviving_status.amt_rate_threshold
+FROM federal_parameters
+    -- the thresholds of each filing status, a year missing any status has no parameters
+    INNER JOIN federal_status_parameters single_status ON federal_parameters.tax_year = single_status.tax_year AND single_status.filing_status = 's'
+    INNER JOIN federal_status_parameters married_status ON federal_parameters.tax_year = married_status.tax_year AND married_status.filing_status = 'm'
+    INNER JOIN federal_status_parameters head_status ON federal_parameters.tax_year = head_status.tax_year AND head_status.filing_status = 'h'
+    INNER JOIN federal_status_parameters separate_status ON federal_parameters.tax_year = separate_status.tax_year AND separate_status.filing_status = 'mfs'
+    INNER JOIN federal_status_parameters surviving_status ON federal_parameters.tax_year = surviving_status.tax_year AND surviving_status.filing_status = 'qss'
+WHERE federal_parameters.tax_year = ?;
\ No newline at end of file
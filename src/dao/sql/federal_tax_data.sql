@@ -1,3 +1,15 @@
-SELECT * 
-FROM federal_brackets, federal_deductions 
+SELECT 
+    federal_brackets.rate,
+    federal_brackets.single_bracket,
+    federal_brackets.married_bracket,
+    federal_brackets.head_bracket,
+    federal_brackets.separate_bracket,
+    federal_brackets.surviving_bracket,
+    federal_deductions.single_deduction,
+    federal_deductions.married_deduction,
+    federal_deductions.head_deduction,
+    federal_deductions.separate_deduction,
+    federal_deductions.surviving_deduction
+FROM federal_brackets INNER JOIN federal_deductions ON federal_brackets.tax_year = federal_deductions.tax_year
+WHERE federal_brackets.tax_year = ?
 ORDER BY RATE;
\ No newline at end of file
@@ -1,5 +1,6 @@
--- get list of available county metrics. Select all fields from county other than name and id
+-- get list of available county metrics. Select all fields from county other than name and id, and the property tax
+-- rate, which is not a whole number like the other metrics
 SELECT column_name
 FROM information_schema.columns
 WHERE table_name = 'county'
-    AND column_name NOT IN ('county_id', 'county_name', 'state_id');
+    AND column_name NOT IN ('county_id', 'county_name', 'state_id', 'property_tax_rate');
@@ -0,0 +1,6 @@
+SELECT 
+    sales_tax_rates.state_id,
+    COALESCE(sales_tax_rates.county_id, 0),
+    COALESCE(sales_tax_rates.tax_locale_id, 0),
+    sales_tax_rates.rate
+FROM sales_tax_rates;
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT 
+    state_reciprocity.resident_state_id,
+    state_reciprocity.work_state_id
+FROM state_reciprocity;
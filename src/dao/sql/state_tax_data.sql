@@ -1,14 +1,28 @@
 SELECT 
     states.state_id,
     states.state_name,
-    states.single_deduction,
-    states.married_deduction,
-    states.single_exemption,
-    states.married_exemption,
-    states.dependent_exemption,
+    COALESCE(state_deductions.single_deduction, 0),
+    COALESCE(state_deductions.married_deduction, 0),
+    COALESCE(state_deductions.head_deduction, state_deductions.single_deduction, 0),
+    COALESCE(state_deductions.single_exemption, 0),
+    COALESCE(state_deductions.married_exemption, 0),
+    COALESCE(state_deductions.head_exemption, state_deductions.single_exemption, 0),
+    COALESCE(state_deductions.dependent_exemption, 0),
     state_brackets.single_rate,
     state_brackets.single_bracket,
     state_brackets.married_rate,
-    state_brackets.married_bracket
-FROM states INNER JOIN state_brackets ON states.state_id = state_brackets.state_id
-WHERE states.state_id != 32767;
\ No newline at end of file
+    state_brackets.married_bracket,
+    COALESCE(state_brackets.head_rate, state_brackets.single_rate),
+    COALESCE(state_brackets.head_bracket, state_brackets.single_bracket),
+    -- states without any head of household columns fall back to the single schedule
+    state_deductions.head_deduction IS NULL AND state_deductions.head_exemption IS NULL AND state_brackets.head_bracket IS NULL,
+    COALESCE(states.eitc_percent, 0),
+    COALESCE(states.capital_gains_exclusion, 0),
+    COALESCE(states.retirement_deductible, true),
+    COALESCE(states.hsa_deductible, true),
+    COALESCE(states.indexed, false)
+FROM states
+    INNER JOIN state_brackets ON states.state_id = state_brackets.state_id
+    -- deductions and exemptions are versioned by tax year like the brackets, states without a row for the year have none
+    LEFT JOIN state_deductions ON states.state_id = state_deductions.state_id AND state_brackets.tax_year = state_deductions.tax_year
+WHERE states.state_id != 32767 AND state_brackets.tax_year = ?;
\ No newline at end of file
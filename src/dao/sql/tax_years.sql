@@ -0,0 +1,8 @@
+SELECT DISTINCT
+    federal_brackets.tax_year
+FROM federal_brackets
+    -- only years with both federal and state brackets are available
+    INNER JOIN state_brackets ON federal_brackets.tax_year = state_brackets.tax_year
+    -- and with the other federal parameters published
+    INNER JOIN federal_parameters ON federal_brackets.tax_year = federal_parameters.tax_year
+ORDER BY federal_brackets.tax_year;
\ No newline at end of file
@@ -3,18 +3,18 @@
 package logging
 
 import (
-	"log"
-	"os"
 	"errors"
+	"fmt"
 	"io"
+	"log"
+	"os"
 	"runtime"
-	"fmt"
 	"strings"
 )
 
 type Logger struct {
-	infoLogger *log.Logger
-	warnLogger *log.Logger
+	infoLogger  *log.Logger
+	warnLogger  *log.Logger
 	errorLogger *log.Logger
 	fatalLogger *log.Logger
 }
@@ -52,7 +52,7 @@ func (l *Logger) inputAsString(a ...interface{}) []interface{} {
 	return n
 }
 
-func (l *Logger) addCaller(s string) string{
+func (l *Logger) addCaller(s string) string {
 	_, file, line, _ := runtime.Caller(2)
 	fileParts := strings.Split(file, "/")
 	source := fileParts[len(fileParts)-1]
@@ -62,12 +62,12 @@ func (l *Logger) addCaller(s string) string{
 // Public method to instantiate base loggers, return aggregated logger object
 func GetLogger(logPath string) (Logger, *os.File) {
 	var file *os.File
-	if exists(logPath){
+	if exists(logPath) {
 		file, _ = os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
 	} else {
 		file, _ = os.Create(logPath)
 	}
-	mw := io.MultiWriter(os.Stdout, file) 
+	mw := io.MultiWriter(os.Stdout, file)
 	// common logging flags
 	flags := log.LstdFlags
 	infoLogger := log.New(mw, "INFO ", flags)
@@ -80,12 +80,12 @@ func GetLogger(logPath string) (Logger, *os.File) {
 
 // helper method to check if given log file already exists
 func exists(name string) bool {
-    _, err := os.Stat(name)
-    if err == nil {
-        return true
-    }
-    if errors.Is(err, os.ErrNotExist) {
-        return false
-    }
-    return false
+	_, err := os.Stat(name)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	return false
 }
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/Matthew-Curry/re-region-api/src/controller"
 	"github.com/Matthew-Curry/re-region-api/src/logging"
@@ -13,6 +14,9 @@ import (
 
 const openApiYml = "docs.yml"
 
+// annual cpi growth used to project unpublished tax years when none is configured
+const defaultCpiFactor = 0.03
+
 var logger logging.Logger
 var logFile *os.File
 
@@ -30,9 +34,13 @@ func main() {
 	dbName := os.Getenv("RE_REGION_DB")
 	dbHost := os.Getenv("DB_HOST")
 	dbPort := os.Getenv("DB_PORT")
+	cpiFactor, convErr := strconv.ParseFloat(os.Getenv("RE_REGION_CPI_FACTOR"), 64)
+	if convErr != nil {
+		cpiFactor = defaultCpiFactor
+	}
 
 	// initialize services in the controller package
-	err := controller.InitServices(dbUser, dbPassword, dbName, dbHost, dbPort)
+	err := controller.InitServices(dbUser, dbPassword, dbName, dbHost, dbPort, cpiFactor)
 	if err != nil {
 		logger.Fatal("Unable to intiialize core services", err.Error())
 	}
@@ -44,6 +52,24 @@ func main() {
 	mux.HandleFunc("/counties", controller.CountyHandler)
 	mux.HandleFunc("/states", controller.StateHandler)
 
+	// take-home pay endpoint
+	mux.HandleFunc("/paycheck", controller.PaycheckHandler)
+
+	// commuter tax endpoint
+	mux.HandleFunc("/commute", controller.CommuteHandler)
+
+	// two-earner household endpoint
+	mux.HandleFunc("/household", controller.HouseholdHandler)
+
+	// region comparison endpoint
+	mux.HandleFunc("/compare", controller.CompareHandler)
+
+	// salary equivalence endpoint
+	mux.HandleFunc("/salary-equivalence", controller.SalaryEquivalenceHandler)
+
+	// gross up endpoint
+	mux.HandleFunc("/gross-up", controller.GrossUpHandler)
+
 	// list endpoints
 	mux.HandleFunc("/county-list", controller.CountyListHandler)
 	mux.HandleFunc("/state-list", controller.StateListHandler)
@@ -65,7 +91,6 @@ func main() {
 		http.ServeFile(w, r, openApiYml)
 	})
 
-
 	// the path for the UI
 	fsys, _ := fs.Sub(content, "static/swagger-ui")
 	mux.Handle("/", http.FileServer(http.FS(fsys)))
@@ -0,0 +1,118 @@
+package model
+
+// exemption, the income where the exemption starts phasing out, and the income where the high rate starts,
+// for a single filing status
+type AlternativeMinimumTaxThresholds struct {
+	Exemption           int
+	Phase_out_threshold int
+	Rate_threshold      int
+}
+
+type AlternativeMinimumTaxInfo struct {
+	// income above the exemption is taxed at the low rate up to the rate threshold and the high rate above it
+	Low_rate  float64
+	High_rate float64
+	// the exemption is reduced by the phase-out rate applied to income above the phase-out threshold
+	Phase_out_rate       float64
+	Single_thresholds    AlternativeMinimumTaxThresholds
+	Married_thresholds   AlternativeMinimumTaxThresholds
+	Head_thresholds      AlternativeMinimumTaxThresholds
+	Separate_thresholds  AlternativeMinimumTaxThresholds
+	Surviving_thresholds AlternativeMinimumTaxThresholds
+}
+
+// constructor for AlternativeMinimumTaxInfo
+func GetAlternativeMinimumTaxInfo(lr, hr, por float64, st, mt, ht, spt, svt AlternativeMinimumTaxThresholds) *AlternativeMinimumTaxInfo {
+	return &AlternativeMinimumTaxInfo{
+		Low_rate:             lr,
+		High_rate:            hr,
+		Phase_out_rate:       por,
+		Single_thresholds:    st,
+		Married_thresholds:   mt,
+		Head_thresholds:      ht,
+		Separate_thresholds:  spt,
+		Surviving_thresholds: svt,
+	}
+}
+
+// public method to project the alternative minimum tax info to a later year, indexing the thresholds of each filing
+// status by the cumulative cpi factor
+func (a *AlternativeMinimumTaxInfo) GetProjection(factor float64) *AlternativeMinimumTaxInfo {
+	return GetAlternativeMinimumTaxInfo(a.Low_rate, a.High_rate, a.Phase_out_rate, a.Single_thresholds.getProjection(factor),
+		a.Married_thresholds.getProjection(factor), a.Head_thresholds.getProjection(factor), a.Separate_thresholds.getProjection(factor),
+		a.Surviving_thresholds.getProjection(factor))
+}
+
+// helper method to index the thresholds of a filing status
+func (t AlternativeMinimumTaxThresholds) getProjection(factor float64) AlternativeMinimumTaxThresholds {
+	return AlternativeMinimumTaxThresholds{
+		Exemption:           IndexAmount(t.Exemption, factor),
+		Phase_out_threshold: IndexAmount(t.Phase_out_threshold, factor),
+		Rate_threshold:      IndexAmount(t.Rate_threshold, factor),
+	}
+}
+
+// inputs to the alternative minimum tax, the tax is the amount the tentative minimum tax exceeds the regular tax
+type AlternativeMinimumTaxBreakdown struct {
+	Amt_income int
+	// exemption after the phase-out
+	Exemption             int
+	Taxable_income        int
+	Tentative_minimum_tax int
+	Regular_tax           int
+	Tax                   int
+}
+
+// public method to get the alternative minimum tax. Preferential income keeps its preferential rates, stacked on
+// top of the rest of the taxable income
+func (a *AlternativeMinimumTaxInfo) GetAlternativeMinimumTax(fs FilingStatus, amtIncome, preferentialIncome, regularTax int,
+	investmentInfo *InvestmentIncomeTaxInfo) AlternativeMinimumTaxBreakdown {
+	var t AlternativeMinimumTaxThresholds
+	switch fs {
+	case Head:
+		t = a.Head_thresholds
+	case Single:
+		t = a.Single_thresholds
+	case Married:
+		t = a.Married_thresholds
+	case MarriedSeparately:
+		t = a.Separate_thresholds
+	case SurvivingSpouse:
+		t = a.Surviving_thresholds
+	default:
+		return AlternativeMinimumTaxBreakdown{}
+	}
+
+	b := AlternativeMinimumTaxBreakdown{Amt_income: amtIncome, Exemption: t.Exemption, Regular_tax: regularTax}
+	if amtIncome > t.Phase_out_threshold {
+		b.Exemption = t.Exemption - int(float64(amtIncome-t.Phase_out_threshold)*a.Phase_out_rate)
+		if b.Exemption < 0 {
+			b.Exemption = 0
+		}
+	}
+
+	b.Taxable_income = amtIncome - b.Exemption
+	if b.Taxable_income < 0 {
+		b.Taxable_income = 0
+	}
+
+	// the low and high rates apply to the income that is not preferential
+	preferentialIncome = minInt(preferentialIncome, b.Taxable_income)
+	ordinaryIncome := b.Taxable_income - preferentialIncome
+	if ordinaryIncome > t.Rate_threshold {
+		b.Tentative_minimum_tax = int(float64(t.Rate_threshold)*a.Low_rate) + int(float64(ordinaryIncome-t.Rate_threshold)*a.High_rate)
+	} else {
+		b.Tentative_minimum_tax = int(float64(ordinaryIncome) * a.Low_rate)
+	}
+	if preferentialIncome > 0 {
+		preferentialTax, _ := investmentInfo.GetPreferentialLiability(fs, ordinaryIncome, preferentialIncome)
+		b.Tentative_minimum_tax = b.Tentative_minimum_tax + preferentialTax
+	}
+
+	b.Tax = b.Tentative_minimum_tax - regularTax
+	if b.Tax < 0 {
+		b.Tax = 0
+	}
+
+	return b
+}
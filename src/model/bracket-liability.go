@@ -0,0 +1,63 @@
+package model
+
+import (
+	"sort"
+)
+
+// the tax owed on the slice of taxable income falling within a single bracket
+type BracketLiability struct {
+	Rate float64
+	// bounds of the bracket, the upper bound is 0 for the highest bracket
+	Lower_bound  int
+	Upper_bound  int
+	Taxed_income int
+	Tax          int
+}
+
+// lower bound of a bracket paired with its rate for a single filing status
+type bracketBound struct {
+	bound int
+	rate  float64
+}
+
+// helper function holding the progressive calculation shared by the federal and state tax info. Each slice of
+// income is taxed at the rate of the bracket it falls in, returns the total and the amount owed per bracket
+func getProgressiveLiability(income int, bounds []bracketBound) (int, []BracketLiability) {
+	// bracket lists are ordered by rate, order by the bound of this filing status to walk the slices of income
+	sort.SliceStable(bounds, func(i, j int) bool { return bounds[i].bound < bounds[j].bound })
+
+	total := 0
+	liabilities := []BracketLiability{}
+	for i, b := range bounds {
+		if income <= b.bound {
+			break
+		}
+
+		// income above the bound is taxed in this bracket up to the start of the next one
+		upper := 0
+		taxed := income - b.bound
+		if i+1 < len(bounds) {
+			upper = bounds[i+1].bound
+			if income > upper {
+				taxed = upper - b.bound
+			}
+		}
+
+		// skip brackets repeated for this filing status
+		if taxed == 0 {
+			continue
+		}
+
+		tax := int(float64(taxed) * b.rate)
+		total = total + tax
+		liabilities = append(liabilities, BracketLiability{
+			Rate:         b.rate,
+			Lower_bound:  b.bound,
+			Upper_bound:  upper,
+			Taxed_income: taxed,
+			Tax:          tax,
+		})
+	}
+
+	return total, liabilities
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// taxes of a filer who lives in one county and works in another
+type Commute struct {
+	Home_county_id   int
+	Home_county_name string
+	Home_state_id    int
+	Home_state_name  string
+	Work_county_id   int
+	Work_county_name string
+	Work_state_id    int
+	Work_state_name  string
+	Tax_year         int
+	Projected        bool
+	// set when a reciprocity agreement between the states exempts the wages from the work state tax
+	Reciprocity bool
+	// an estimate for each pair of tax locales in the home and work counties
+	Tax_locale []CommuteLocale
+}
+
+// taxes for living in the home locale as a resident and working in the work locale as a nonresident
+type CommuteLocale struct {
+	Home_locale_id   int
+	Home_locale_name string
+	Work_locale_id   int
+	Work_locale_name string
+	Total_tax        int
+	Federal_tax      int
+	// home state tax is net of the credit for tax paid to the work state
+	Home_state_tax     int
+	Work_state_tax     int
+	Other_state_credit int
+	Home_locale_tax    int
+	Work_locale_tax    int
+	Payroll_tax        int
+	// set when refundable credits exceed the taxes owed and the total tax is a refund
+	Net_refund bool
+	// line by line computation of the tax metrics, only populated on request
+	Breakdown *TaxBreakdown `json:",omitempty"`
+}
+
+// marshaller for controller
+func (c *Commute) MarshallCommute() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(c)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,232 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// kinds of regions that can be compared
+const (
+	CountyRegion = "county"
+	StateRegion  = "state"
+)
+
+// a county or state reduced to the census metrics, tax components, and credits both share, so regions of either kind
+// compare field by field. The taxes of a county are those of one of its tax locales
+type ComparisonRegion struct {
+	Region_type string
+	Id          int
+	Name        string
+	State_id    int
+	State_name  string
+	// tax locale of a county the taxes are estimated in, not set for states
+	Locale_id   int    `json:",omitempty"`
+	Locale_name string `json:",omitempty"`
+	// census metrics
+	Pop           int
+	Male_pop      int
+	Female_pop    int
+	Median_income int
+	Average_rent  int
+	Commute       int
+	// the tax components, locale tax and property tax are always 0 for states
+	Total_tax                 int
+	Federal_tax               int
+	State_tax                 int
+	Locale_tax                int
+	Payroll_tax               int
+	Sales_tax                 int
+	Property_tax              int
+	Amt_tax                   int
+	Net_investment_income_tax int
+	// credits applied in the federal and state taxes
+	Child_tax_credit           int
+	Other_dependent_credit     int
+	Earned_income_credit       int
+	State_earned_income_credit int
+	// income left after the total tax and a year of the average rent
+	Disposable_income int
+}
+
+// the difference in a census metric, tax component, or credit from the origin to the destination
+type MetricDifference struct {
+	Metric      string
+	Origin      int
+	Destination int
+	Difference  int
+	// difference as a percent of the origin value, not set when the origin value is 0
+	Percent_difference *float64 `json:",omitempty"`
+}
+
+// the origin and destination of a move side by side, with the difference in each census metric, tax component, and credit
+type Comparison struct {
+	Tax_year    int
+	Projected   bool
+	Origin      ComparisonRegion
+	Destination ComparisonRegion
+	Differences []MetricDifference
+}
+
+// public function to get the comparison region of a county, with the taxes of the tax locale with the given id. Returns
+// false if the county has no such tax locale
+func GetCountyComparisonRegion(c *County, localeId int) (ComparisonRegion, bool) {
+	for _, tl := range c.Tax_locale {
+		if tl.Locale_id == localeId {
+			return getCountyComparisonRegion(c, tl), true
+		}
+	}
+
+	return ComparisonRegion{}, false
+}
+
+// public function to get the comparison region of a county, with the taxes of its first tax locale
+func GetDefaultCountyComparisonRegion(c *County) ComparisonRegion {
+	if len(c.Tax_locale) == 0 {
+		return getCountyComparisonRegion(c, TaxLocale{})
+	}
+
+	return getCountyComparisonRegion(c, c.Tax_locale[0])
+}
+
+// helper function to build the comparison region of a county and one of its tax locales
+func getCountyComparisonRegion(c *County, tl TaxLocale) ComparisonRegion {
+	return ComparisonRegion{
+		Region_type:                CountyRegion,
+		Id:                         c.County_id,
+		Name:                       c.County_name,
+		State_id:                   c.State_id,
+		State_name:                 c.State_name,
+		Locale_id:                  tl.Locale_id,
+		Locale_name:                tl.Locale_name,
+		Pop:                        c.Pop,
+		Male_pop:                   c.Male_pop,
+		Female_pop:                 c.Female_pop,
+		Median_income:              c.Median_income,
+		Average_rent:               c.Average_rent,
+		Commute:                    c.Commute,
+		Total_tax:                  tl.Total_tax,
+		Federal_tax:                tl.Federal_tax,
+		State_tax:                  tl.State_tax,
+		Locale_tax:                 tl.Locale_tax,
+		Payroll_tax:                tl.Payroll_tax,
+		Sales_tax:                  tl.Sales_tax,
+		Property_tax:               c.Property_tax,
+		Amt_tax:                    tl.Amt_tax,
+		Net_investment_income_tax:  tl.Net_investment_income_tax,
+		Child_tax_credit:           tl.Child_tax_credit,
+		Other_dependent_credit:     tl.Other_dependent_credit,
+		Earned_income_credit:       tl.Earned_income_credit,
+		State_earned_income_credit: tl.State_earned_income_credit,
+		Disposable_income:          tl.Disposable_income.Disposable_income,
+	}
+}
+
+// public function to get the comparison region of a state
+func GetStateComparisonRegion(s *State) ComparisonRegion {
+	return ComparisonRegion{
+		Region_type:                StateRegion,
+		Id:                         s.State_id,
+		Name:                       s.State_name,
+		State_id:                   s.State_id,
+		State_name:                 s.State_name,
+		Pop:                        s.Pop,
+		Male_pop:                   s.Male_pop,
+		Female_pop:                 s.Female_pop,
+		Median_income:              s.Median_income,
+		Average_rent:               s.Average_rent,
+		Commute:                    s.Commute,
+		Total_tax:                  s.Total_tax,
+		Federal_tax:                s.Federal_tax,
+		State_tax:                  s.State_tax,
+		Payroll_tax:                s.Payroll_tax,
+		Sales_tax:                  s.Sales_tax,
+		Amt_tax:                    s.Amt_tax,
+		Net_investment_income_tax:  s.Net_investment_income_tax,
+		Child_tax_credit:           s.Child_tax_credit,
+		Other_dependent_credit:     s.Other_dependent_credit,
+		Earned_income_credit:       s.Earned_income_credit,
+		State_earned_income_credit: s.State_earned_income_credit,
+		Disposable_income:          s.Disposable_income.Disposable_income,
+	}
+}
+
+// the census metrics, tax components, and credits of a region in the order they are compared
+func (r ComparisonRegion) getMetricValues() []metricValue {
+	return []metricValue{
+		{"pop", r.Pop},
+		{"male_pop", r.Male_pop},
+		{"female_pop", r.Female_pop},
+		{"median_income", r.Median_income},
+		{"average_rent", r.Average_rent},
+		{"commute", r.Commute},
+		{"total_tax", r.Total_tax},
+		{"federal_tax", r.Federal_tax},
+		{"state_tax", r.State_tax},
+		{"locale_tax", r.Locale_tax},
+		{"payroll_tax", r.Payroll_tax},
+		{"sales_tax", r.Sales_tax},
+		{"property_tax", r.Property_tax},
+		{"amt_tax", r.Amt_tax},
+		{"net_investment_income_tax", r.Net_investment_income_tax},
+		{"child_tax_credit", r.Child_tax_credit},
+		{"other_dependent_credit", r.Other_dependent_credit},
+		{"earned_income_credit", r.Earned_income_credit},
+		{"state_earned_income_credit", r.State_earned_income_credit},
+		{"disposable_income", r.Disposable_income},
+	}
+}
+
+// a named census metric, tax component, or credit of a region
+type metricValue struct {
+	name  string
+	value int
+}
+
+// public function to compare the origin and destination of a move, both estimated for the same filer and tax year
+func GetComparison(origin, destination ComparisonRegion, year int, projected bool) *Comparison {
+	c := &Comparison{
+		Tax_year:    year,
+		Projected:   projected,
+		Origin:      origin,
+		Destination: destination,
+		Differences: []MetricDifference{},
+	}
+
+	destinationValues := destination.getMetricValues()
+	for i, o := range origin.getMetricValues() {
+		d := MetricDifference{
+			Metric:      o.name,
+			Origin:      o.value,
+			Destination: destinationValues[i].value,
+			Difference:  destinationValues[i].value - o.value,
+		}
+		d.Percent_difference = getPercentDifference(o.value, d.Difference)
+		c.Differences = append(c.Differences, d)
+	}
+
+	return c
+}
+
+// helper function to get a difference as a percent of the value it is from, rounded to two decimal places. Returns nil
+// when the value is 0
+func getPercentDifference(value, difference int) *float64 {
+	if value == 0 {
+		return nil
+	}
+
+	percent := math.Round(float64(difference)/math.Abs(float64(value))*10000) / 100
+	return &percent
+}
+
+// marshaller for controller
+func (c *Comparison) MarshallComparison() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(c)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
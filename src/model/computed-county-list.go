@@ -0,0 +1,139 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// metrics computed for the filer in each county rather than read from the county table
+const (
+	TotalTaxMetric         = "total_tax"
+	EffectiveTaxRateMetric = "effective_tax_rate"
+	DisposableIncomeMetric = "disposable_income"
+	SalesTaxMetric         = "sales_tax"
+)
+
+// list of counties ranked by a metric computed for the filer, for the tax year the metric is estimated for
+type ComputedCountyList struct {
+	Metric_name string
+	Tax_year    int
+	Projected   bool
+	Ranked_list []ComputedCountyMetricPair
+}
+
+type ComputedCountyMetricPair struct {
+	County_id   int
+	County_name string
+	State_id    int
+	State_name  string
+	// tax locale the metric is estimated in, not set for counties without a tax locale
+	Locale_id   int    `json:",omitempty"`
+	Locale_name string `json:",omitempty"`
+	// amounts are whole dollars, the effective tax rate is a share of gross income
+	Metric_value float64
+}
+
+// constructor for a computed metric county list
+func GetComputedCountyList(metric string, year int, projected bool) *ComputedCountyList {
+	return &ComputedCountyList{Metric_name: metric, Tax_year: year, Projected: projected, Ranked_list: []ComputedCountyMetricPair{}}
+}
+
+// public function to check if a metric is computed for the filer
+func IsComputedCountyMetric(metric string) bool {
+	switch strings.TrimSpace(strings.ToLower(metric)) {
+	case TotalTaxMetric, EffectiveTaxRateMetric, DisposableIncomeMetric, SalesTaxMetric:
+		return true
+	}
+
+	return false
+}
+
+// metric of a tax locale estimated for the filer, with the tax the tax locales of a county are chosen by
+type LocaleMetric struct {
+	Locale_id    int
+	Locale_name  string
+	Ranking_tax  int
+	Metric_value float64
+}
+
+// public function to get the metric of a tax locale from the estimate of its taxes. A county with several tax locales
+// is ranked by the one with the lowest total tax, which also leaves the filer the most income. Sales tax is not part of
+// the total tax, so the sales tax metric is ranked by the locale with the lowest sales tax and only needs the sales tax
+// of the breakdown estimated
+func GetLocaleMetric(metric string, localeId int, localeName string, b *TaxBreakdown, averageRent int) LocaleMetric {
+	lm := LocaleMetric{Locale_id: localeId, Locale_name: localeName}
+	if metric == SalesTaxMetric {
+		lm.Ranking_tax = b.Sales_tax.Tax
+		lm.Metric_value = float64(b.Sales_tax.Tax)
+		return lm
+	}
+
+	lm.Ranking_tax = b.GetTotalTax()
+	switch metric {
+	case TotalTaxMetric:
+		lm.Metric_value = float64(lm.Ranking_tax)
+	case EffectiveTaxRateMetric:
+		lm.Metric_value = GetDisposableIncome(b.Gross_income, lm.Ranking_tax, b.Contributions.GetPayDeduction(), averageRent).Tax_to_income
+	case DisposableIncomeMetric:
+		lm.Metric_value = float64(GetDisposableIncome(b.Gross_income, lm.Ranking_tax, b.Contributions.GetPayDeduction(), averageRent).Disposable_income)
+	}
+
+	return lm
+}
+
+// public function to get the computed metric of a county from the metric of each of its tax locales, taken from the
+// tax locale with the lowest ranking tax
+func GetComputedCountyMetricPair(c *County, locales []LocaleMetric) ComputedCountyMetricPair {
+	cmp := ComputedCountyMetricPair{
+		County_id:   c.County_id,
+		County_name: c.County_name,
+		State_id:    c.State_id,
+		State_name:  c.State_name,
+	}
+
+	if len(locales) == 0 {
+		return cmp
+	}
+
+	lm := locales[0]
+	for _, l := range locales[1:] {
+		if l.Ranking_tax < lm.Ranking_tax {
+			lm = l
+		}
+	}
+	cmp.Locale_id = lm.Locale_id
+	cmp.Locale_name = lm.Locale_name
+	cmp.Metric_value = lm.Metric_value
+
+	return cmp
+}
+
+// order the ranked list by metric value and keep the first n counties, ties are broken by county id so the
+// ranking is the same from one request to the next
+func (c *ComputedCountyList) RankCountyList(n int, desc bool) {
+	sort.Slice(c.Ranked_list, func(i, j int) bool {
+		a, b := c.Ranked_list[i], c.Ranked_list[j]
+		if a.Metric_value != b.Metric_value {
+			return (a.Metric_value < b.Metric_value) != desc
+		}
+		return a.County_id < b.County_id
+	})
+
+	if n > 0 && n < len(c.Ranked_list) {
+		c.Ranked_list = c.Ranked_list[:n]
+	}
+}
+
+// getter method for the controller to be able to marhsall private fields
+func (c *ComputedCountyList) MarshallCountyList() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(c)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -31,6 +31,11 @@ type TaxLocaleInfo struct {
 	Nonresident_year_fee       float64
 	Nonresident_pay_period_fee float64
 	Nonresident_state_rate     float64
+	// whether the local rate and fees apply to net self-employment earnings
+	Self_employment_taxed bool
+	// whether retirement and hsa contributions reduce the wages the local rate applies to
+	Retirement_deductible bool
+	Hsa_deductible        bool
 }
 
 // marshallers for controller
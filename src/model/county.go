@@ -11,6 +11,10 @@ type County struct {
 	County_name string
 	State_id    int
 	State_name  string
+	// tax year of the brackets and deductions the estimates are made with
+	Tax_year int
+	// set when the year is not yet published, the estimates then use tables projected for inflation
+	Projected bool
 	// county metrics
 	Pop           int
 	Male_pop      int
@@ -18,6 +22,13 @@ type County struct {
 	Median_income int
 	Average_rent  int
 	Commute       int
+	// median home value and the effective property tax rate charged on home values in the county
+	Median_home_value int
+	Property_tax_rate float64
+	// estimated annual property tax on the home value of the filer, the county median when not given. Not part of the
+	// total tax of the tax locales
+	Home_value   int
+	Property_tax int
 	// list of tax jurisdictions with tax information
 	Tax_locale []TaxLocale
 }
@@ -29,6 +40,35 @@ type TaxLocale struct {
 	Federal_tax int
 	State_tax   int
 	Locale_tax  int
+	Payroll_tax int
+	// sales tax on the spending of the filer at the combined state, county, and locale rate, not part of the total tax
+	Sales_tax      int
+	Sales_tax_rate float64
+	// alternative minimum tax and net investment income tax included in the federal tax
+	Amt_tax                   int
+	Net_investment_income_tax int
+	// credits applied in the federal and state taxes
+	Child_tax_credit           int
+	Other_dependent_credit     int
+	Earned_income_credit       int
+	State_earned_income_credit int
+	// whether the federal tax took the standard or itemized deduction
+	Federal_deduction_type string
+	// set when refundable credits exceed the taxes owed and the total tax is a refund
+	Net_refund bool
+	// income left after the total tax and a year of the average rent, with the rent and tax to income ratios
+	Disposable_income DisposableIncome
+	// set when the state has no schedule for the filing status and the schedule of another status is used
+	State_schedule_fallback bool
+	// suggested estimated payments, only populated for the self-employed
+	Quarterly_estimates []EstimatedPayment `json:",omitempty"`
+	// line by line computation of the tax metrics, only populated on request
+	Breakdown *TaxBreakdown `json:",omitempty"`
+}
+
+// public function to estimate the annual property tax on a home at an effective property tax rate
+func GetPropertyTax(homeValue int, rate float64) int {
+	return int(float64(homeValue) * rate)
 }
 
 // marshaller for controller
@@ -0,0 +1,44 @@
+package model
+
+import "math"
+
+// months of rent paid in a year
+const rentMonths = 12
+
+// income left to the filer after taxes, the pre-tax contributions taken from pay, and a year of rent at the average
+// rent of the region
+type DisposableIncome struct {
+	Gross_income          int
+	Total_tax             int
+	Pre_tax_contributions int
+	Annual_rent           int
+	// gross income less the total tax, the pre-tax contributions, and the annual rent
+	Disposable_income int
+	// shares of gross income spent on rent and taxes, 0 when there is no income
+	Rent_to_income float64
+	Tax_to_income  float64
+}
+
+// public function to get the disposable income of a filer with the given gross income, total tax, and pre-tax
+// contributions taken from pay, paying the average monthly rent of a region
+func GetDisposableIncome(grossIncome, totalTax, contributions, averageRent int) DisposableIncome {
+	d := DisposableIncome{
+		Gross_income:          grossIncome,
+		Total_tax:             totalTax,
+		Pre_tax_contributions: contributions,
+		Annual_rent:           averageRent * rentMonths,
+	}
+	d.Disposable_income = d.Gross_income - d.Total_tax - d.Pre_tax_contributions - d.Annual_rent
+
+	if d.Gross_income > 0 {
+		d.Rent_to_income = getIncomeRatio(d.Annual_rent, d.Gross_income)
+		d.Tax_to_income = getIncomeRatio(d.Total_tax, d.Gross_income)
+	}
+
+	return d
+}
+
+// helper function to get an amount as a share of income, rounded to four decimal places
+func getIncomeRatio(amount, income int) float64 {
+	return math.Round(float64(amount)/float64(income)*10000) / 10000
+}
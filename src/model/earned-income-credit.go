@@ -0,0 +1,107 @@
+package model
+
+// earned income credit parameters for a number of qualifying children
+type EarnedIncomeCreditSchedule struct {
+	Children int
+	// the credit phases in at the credit rate until it reaches the max credit
+	Credit_rate float64
+	Max_credit  int
+	// the credit phases out at the phase-out rate on income above the phase-out threshold
+	Phase_out_rate              float64
+	Single_phase_out_threshold  int
+	Married_phase_out_threshold int
+}
+
+type EarnedIncomeCreditInfo struct {
+	// filers with investment income above the limit cannot claim the credit
+	Investment_income_limit int
+	// schedules ordered by number of children, the last schedule applies to any larger number of children
+	schedule_list []EarnedIncomeCreditSchedule
+}
+
+// constructor for EarnedIncomeCreditInfo
+func GetEarnedIncomeCreditInfo(iil int, scheduleList []EarnedIncomeCreditSchedule) *EarnedIncomeCreditInfo {
+	return &EarnedIncomeCreditInfo{Investment_income_limit: iil, schedule_list: scheduleList}
+}
+
+// public method to project the earned income credit to a later year, indexing the investment income limit, max
+// credits, and phase-out thresholds by the cumulative cpi factor
+func (e *EarnedIncomeCreditInfo) GetProjection(factor float64) *EarnedIncomeCreditInfo {
+	scheduleList := make([]EarnedIncomeCreditSchedule, len(e.schedule_list))
+	for i, s := range e.schedule_list {
+		scheduleList[i] = EarnedIncomeCreditSchedule{
+			Children:                    s.Children,
+			Credit_rate:                 s.Credit_rate,
+			Max_credit:                  IndexAmount(s.Max_credit, factor),
+			Phase_out_rate:              s.Phase_out_rate,
+			Single_phase_out_threshold:  IndexAmount(s.Single_phase_out_threshold, factor),
+			Married_phase_out_threshold: IndexAmount(s.Married_phase_out_threshold, factor),
+		}
+	}
+
+	return GetEarnedIncomeCreditInfo(IndexAmount(e.Investment_income_limit, factor), scheduleList)
+}
+
+// public method to get the earned income credit applied against the given tax. The credit is fully refundable,
+// the portion beyond the tax is the refundable portion
+func (e *EarnedIncomeCreditInfo) GetEarnedIncomeCredit(fs FilingStatus, agi, earnedIncome, investmentIncome, tax, children int) TaxCredit {
+	credit := TaxCredit{Name: EarnedIncomeCredit, Qualifying: children}
+	if len(e.schedule_list) == 0 || earnedIncome <= 0 || investmentIncome > e.Investment_income_limit {
+		return credit
+	}
+
+	s := e.schedule_list[len(e.schedule_list)-1]
+	if children < len(e.schedule_list) {
+		s = e.schedule_list[children]
+	}
+
+	// married filers filing separately cannot claim the credit, married joint filers have the higher threshold
+	var threshold int
+	switch fs {
+	case Married:
+		threshold = s.Married_phase_out_threshold
+	case Single, Head, SurvivingSpouse:
+		threshold = s.Single_phase_out_threshold
+	default:
+		return credit
+	}
+
+	credit.Base_credit = minInt(int(float64(earnedIncome)*s.Credit_rate), s.Max_credit)
+
+	// the phase-out uses the larger of earned income and adjusted gross income
+	phaseOutIncome := earnedIncome
+	if agi > phaseOutIncome {
+		phaseOutIncome = agi
+	}
+	credit.Phase_out = credit.Base_credit
+	if phaseOutIncome > threshold {
+		credit.Phase_out = credit.Base_credit - int(float64(phaseOutIncome-threshold)*s.Phase_out_rate)
+	}
+	if credit.Phase_out < 0 {
+		credit.Phase_out = 0
+	}
+
+	credit.Nonrefundable = minInt(credit.Phase_out, tax)
+	if credit.Nonrefundable < 0 {
+		credit.Nonrefundable = 0
+	}
+	credit.Refundable = credit.Phase_out - credit.Nonrefundable
+	credit.Amount = credit.Phase_out
+
+	return credit
+}
+
+// get a state earned income credit as a percent of the federal credit, the state credit is fully refundable
+func GetStateEarnedIncomeCredit(federalCredit TaxCredit, percent float64, tax int) TaxCredit {
+	credit := TaxCredit{Name: StateEarnedIncomeCredit, Qualifying: federalCredit.Qualifying}
+	credit.Base_credit = int(float64(federalCredit.Amount) * percent)
+	credit.Phase_out = credit.Base_credit
+	credit.Nonrefundable = minInt(credit.Phase_out, tax)
+	if credit.Nonrefundable < 0 {
+		credit.Nonrefundable = 0
+	}
+	credit.Refundable = credit.Phase_out - credit.Nonrefundable
+	credit.Amount = credit.Phase_out
+
+	return credit
+}
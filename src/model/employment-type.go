@@ -0,0 +1,28 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// "enum" for employment type request param
+type EmploymentType string
+
+const (
+	Employee     EmploymentType = "employee"
+	SelfEmployed                = "self"
+)
+
+// empty strings default to an employee since the param is optional
+func ToEmploymentType(s string) (EmploymentType, error) {
+	s = strings.ToLower(s)
+	if s == "" {
+		return Employee, nil
+	}
+	if s != "employee" && s != "self" {
+		return Employee, errors.New(fmt.Sprintf("%s is not a valid employment type.", s))
+	}
+
+	return EmploymentType(s), nil
+}
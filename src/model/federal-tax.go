@@ -8,61 +8,111 @@ import (
 )
 
 type FederalTaxInfo struct {
+	Tax_year int
+	// set when the year is not yet published and the tables are indexed from the latest published year
+	Projected bool
 	// deduciton/exemption info common across the brackets
-	Single_deduction  int
-	Married_deduction int
-	Head_deduction    int
+	Single_deduction    int
+	Married_deduction   int
+	Head_deduction      int
+	Separate_deduction  int
+	Surviving_deduction int
 	// list of brackets and rates
 	bracket_list []FederalBracket
 }
 
 type FederalBracket struct {
-	Rate            float64
-	Single_bracket  int
-	Married_bracket int
-	Head_bracket    int
+	Rate              float64
+	Single_bracket    int
+	Married_bracket   int
+	Head_bracket      int
+	Separate_bracket  int
+	Surviving_bracket int
 }
 
 // constructor for a FederalTaxInfo, bracket list is private to enforce ordering
-func GetFederalTaxInfo(sd, md, hd int, bracketList []FederalBracket) *FederalTaxInfo {
+func GetFederalTaxInfo(year, sd, md, hd, spd, svd int, bracketList []FederalBracket) *FederalTaxInfo {
 	return &FederalTaxInfo{
-		Single_deduction:  sd,
-		Married_deduction: md,
-		Head_deduction:    hd,
-		bracket_list:      bracketList}
+		Tax_year:            year,
+		Single_deduction:    sd,
+		Married_deduction:   md,
+		Head_deduction:      hd,
+		Separate_deduction:  spd,
+		Surviving_deduction: svd,
+		bracket_list:        bracketList}
 }
 
-// public method to use private bracket list to get the single state tax liability
-func (f *FederalTaxInfo) GetSingleTaxLiability(income int) int {
-	i := sort.Search(len(f.bracket_list), func(i int) bool { return f.bracket_list[i].Single_bracket >= income })
-	// subtract one if in highest bracket
-	if i == len(f.bracket_list) {
-		i = i - 1
+// public method to project the tax info to a later year, indexing the brackets and deductions by the
+// cumulative cpi factor since this year
+func (f *FederalTaxInfo) GetProjection(year int, factor float64) *FederalTaxInfo {
+	bracketList := make([]FederalBracket, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bracketList[i] = FederalBracket{
+			Rate:              b.Rate,
+			Single_bracket:    IndexAmount(b.Single_bracket, factor),
+			Married_bracket:   IndexAmount(b.Married_bracket, factor),
+			Head_bracket:      IndexAmount(b.Head_bracket, factor),
+			Separate_bracket:  IndexAmount(b.Separate_bracket, factor),
+			Surviving_bracket: IndexAmount(b.Surviving_bracket, factor),
+		}
 	}
-	r := f.bracket_list[i].Rate
-	return int(float64(income) * r)
+
+	p := GetFederalTaxInfo(year, IndexAmount(f.Single_deduction, factor), IndexAmount(f.Married_deduction, factor),
+		IndexAmount(f.Head_deduction, factor), IndexAmount(f.Separate_deduction, factor), IndexAmount(f.Surviving_deduction, factor),
+		bracketList)
+	p.Projected = true
+
+	return p
 }
 
-// public method to use private bracket list to get the state tax liability
-func (f *FederalTaxInfo) GetMarriedTaxLiability(income int) int {
-	i := sort.Search(len(f.bracket_list), func(i int) bool { return f.bracket_list[i].Married_bracket >= income })
-	// subtract one if in highest bracket
-	if i == len(f.bracket_list) {
-		i = i - 1
+// public method to use private bracket list to get the single tax liability and the amount owed per bracket
+func (f *FederalTaxInfo) GetSingleTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bounds[i] = bracketBound{bound: b.Single_bracket, rate: b.Rate}
+	}
+
+	return getProgressiveLiability(income, bounds)
+}
+
+// public method to use private bracket list to get the married tax liability and the amount owed per bracket
+func (f *FederalTaxInfo) GetMarriedTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bounds[i] = bracketBound{bound: b.Married_bracket, rate: b.Rate}
 	}
-	r := f.bracket_list[i].Rate
-	return int(float64(income) * r)
+
+	return getProgressiveLiability(income, bounds)
 }
 
-// public method to use private bracket list to get the head tax liability
-func (f *FederalTaxInfo) GetHeadTaxLiability(income int) int {
-	i := sort.Search(len(f.bracket_list), func(i int) bool { return f.bracket_list[i].Head_bracket >= income })
-	// subtract one if in highest bracket
-	if i == len(f.bracket_list) {
-		i = i - 1
+// public method to use private bracket list to get the head tax liability and the amount owed per bracket
+func (f *FederalTaxInfo) GetHeadTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bounds[i] = bracketBound{bound: b.Head_bracket, rate: b.Rate}
 	}
-	r := f.bracket_list[i].Rate
-	return int(float64(income) * r)
+
+	return getProgressiveLiability(income, bounds)
+}
+
+// public method to use private bracket list to get the married filing separately tax liability and the amount owed per bracket
+func (f *FederalTaxInfo) GetSeparateTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bounds[i] = bracketBound{bound: b.Separate_bracket, rate: b.Rate}
+	}
+
+	return getProgressiveLiability(income, bounds)
+}
+
+// public method to use private bracket list to get the qualifying surviving spouse tax liability and the amount owed per bracket
+func (f *FederalTaxInfo) GetSurvivingTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(f.bracket_list))
+	for i, b := range f.bracket_list {
+		bounds[i] = bracketBound{bound: b.Surviving_bracket, rate: b.Rate}
+	}
+
+	return getProgressiveLiability(income, bounds)
 }
 
 // add pairs to the orderd list
@@ -85,15 +135,23 @@ func (f *FederalTaxInfo) AppendToOrderedList(bracket FederalBracket) {
 // getter method for the controller to be able to marhsall private fields
 func (f *FederalTaxInfo) MarshallFederalTaxInfo() ([]byte, *apperrors.AppError) {
 	r, err := json.Marshal(struct {
-		Single_deduction  int
-		Married_deduction int
-		Head_deduction    int
-		Bracket_list      []FederalBracket
+		Tax_year            int
+		Projected           bool
+		Single_deduction    int
+		Married_deduction   int
+		Head_deduction      int
+		Separate_deduction  int
+		Surviving_deduction int
+		Bracket_list        []FederalBracket
 	}{
-		Single_deduction:  f.Single_deduction,
-		Married_deduction: f.Married_deduction,
-		Head_deduction:    f.Head_deduction,
-		Bracket_list:      f.bracket_list,
+		Tax_year:            f.Tax_year,
+		Projected:           f.Projected,
+		Single_deduction:    f.Single_deduction,
+		Married_deduction:   f.Married_deduction,
+		Head_deduction:      f.Head_deduction,
+		Separate_deduction:  f.Separate_deduction,
+		Surviving_deduction: f.Surviving_deduction,
+		Bracket_list:        f.bracket_list,
 	})
 
 	if err != nil {
@@ -0,0 +1,58 @@
+package model
+
+// tax filer variables given with a request, used to estimate taxes for a region
+type FilerProfile struct {
+	Filing_status FilingStatus
+	Resident      bool
+	Dependents    int
+	// dependents that are children qualifying for the child tax credit, the rest are other dependents
+	Qualifying_children int
+	// wages, or net earnings for the self-employed
+	Income int
+	// investment income, long-term gains and qualified dividends are taxed federally at preferential rates
+	Interest            int
+	Short_term_gains    int
+	Long_term_gains     int
+	Qualified_dividends int
+	// pre-tax contributions before the annual limits are applied
+	Retirement_contributions  int
+	Hsa_contributions         int
+	Section_125_contributions int
+	Ira_contributions         int
+	// whether the hsa has the family contribution limit
+	Hsa_family bool
+	// expenses deductible when itemizing, property tax counts toward the state and local tax deduction
+	Mortgage_interest int
+	Charitable_gifts  int
+	Medical_expenses  int
+	Property_tax      int
+	// home value the county property tax is estimated on, 0 estimates on the county median home value
+	Home_value int
+	// whether the estimated county property tax is deducted as the property tax paid when none is given
+	Deduct_property_tax bool
+	// annual spending subject to sales tax, 0 estimates the spending from income
+	Annual_spending int
+	// income is treated as net self-employment earnings rather than wages for self-employed filers
+	Employment_type EmploymentType
+	// whether to leave social security and medicare out of the estimate
+	Exclude_payroll bool
+	// sets the pay periods per pay period fees are charged over
+	Pay_frequency PayFrequency
+	// tax year the brackets and deductions are taken from, 0 for the default year
+	Tax_year int
+}
+
+// income across all income types
+func (f FilerProfile) GetTotalIncome() int {
+	return f.Income + f.GetInvestmentIncome()
+}
+
+// income from interest, gains, and dividends
+func (f FilerProfile) GetInvestmentIncome() int {
+	return f.Interest + f.Short_term_gains + f.Long_term_gains + f.Qualified_dividends
+}
+
+// income taxed federally at the preferential rates
+func (f FilerProfile) GetPreferentialIncome() int {
+	return f.Long_term_gains + f.Qualified_dividends
+}
@@ -1,25 +1,27 @@
 package model
 
 import (
-	"strings"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // "enum" for filing status request param
 type FilingStatus string
 
 const (
-	Head    FilingStatus = "h"
-	Single               = "s"
-	Married              = "m"
+	Head              FilingStatus = "h"
+	Single                         = "s"
+	Married                        = "m"
+	MarriedSeparately              = "mfs"
+	SurvivingSpouse                = "qss"
 )
 
-func ToFilingStatus(s string) (FilingStatus, error){
+func ToFilingStatus(s string) (FilingStatus, error) {
 	s = strings.ToLower(s)
-	if s != "h" && s != "s" && s != "m" {
+	if s != "h" && s != "s" && s != "m" && s != "mfs" && s != "qss" {
 		return FilingStatus("s"), errors.New(fmt.Sprintf("%s is not a valid filing status.", s))
-	} 
+	}
 
 	return FilingStatus(s), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// gross income needed in a county or state for the filer to take home a target net income
+type GrossUp struct {
+	Tax_year  int
+	Projected bool
+	// net income is the gross income less the total tax and the pre-tax contributions taken from pay
+	Target_net_income int
+	Gross_income      int
+	Net_income        int
+	// net income above the target. One dollar less of gross income falls short of the target, so the net income is only
+	// above the tolerance where flat fees or phase-outs make the net income jump between one dollar of income and the next
+	Net_difference   int
+	Tolerance        int
+	Within_tolerance bool
+	// amounts per paycheck, only set when the target is a net pay per paycheck
+	Pay_frequency  PayFrequency `json:",omitempty"`
+	Pay_periods    int          `json:",omitempty"`
+	Target_net_pay float64      `json:",omitempty"`
+	Gross_pay      float64      `json:",omitempty"`
+	Net_pay        float64      `json:",omitempty"`
+	// the region estimated at the gross income
+	Region ComparisonRegion
+}
+
+// public function to get the income taken home from a gross income
+func GetNetIncome(grossIncome, totalTax, contributions int) int {
+	return grossIncome - totalTax - contributions
+}
+
+// public function to find the gross income at which the net income reaches the target. Gross income is at least the
+// net income, so the search starts from the target. Returns false if no income up to the max searched reaches the
+// target, or the error estimating an income
+func GetGrossIncome(target int, netAt func(income int) (int, *apperrors.AppError)) (int, bool, *apperrors.AppError) {
+	return searchIncome(target, target, netAt)
+}
+
+// public function to get the gross up of a target net income, given the region estimated at the gross income found
+func GetGrossUp(region ComparisonRegion, target, grossIncome, netIncome, tolerance, year int, projected bool) *GrossUp {
+	g := &GrossUp{
+		Tax_year:          year,
+		Projected:         projected,
+		Target_net_income: target,
+		Gross_income:      grossIncome,
+		Net_income:        netIncome,
+		Net_difference:    netIncome - target,
+		Tolerance:         tolerance,
+		Region:            region,
+	}
+	g.Within_tolerance = g.Net_difference <= g.Tolerance
+
+	return g
+}
+
+// set the amounts per paycheck of a gross up for a target net pay per paycheck
+func (g *GrossUp) SetPaycheck(p PayFrequency, targetNetPay float64) {
+	g.Pay_frequency = p
+	g.Pay_periods = p.GetPayPeriods()
+	g.Target_net_pay = targetNetPay
+	g.Gross_pay = GetPerPeriodAmount(g.Gross_income, g.Pay_periods)
+	g.Net_pay = GetPerPeriodAmount(g.Net_income, g.Pay_periods)
+}
+
+// marshaller for controller
+func (g *GrossUp) MarshallGrossUp() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(g)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// wages of an earner in a household and the county they work in
+type EarnerProfile struct {
+	Income int
+	Work   *CountyTaxList
+}
+
+// wages of an earner in a household and the state and tax locale they work in
+type EarnerWork struct {
+	Income        int
+	Work_state_id int
+	Work_locale   TaxLocaleInfo
+}
+
+// taxes of a household filing one return, whose earners may each work in a different county
+type Household struct {
+	County_id   int
+	County_name string
+	State_id    int
+	State_name  string
+	Tax_year    int
+	Projected   bool
+	// an estimate for each tax locale of the home county and the tax locales the earners work in
+	Tax_locale []HouseholdLocale
+}
+
+// joint federal and state taxes of the household, and the local and payroll taxes of each earner
+type HouseholdLocale struct {
+	Locale_id   int
+	Locale_name string
+	Total_tax   int
+	Federal_tax int
+	// state tax is net of the credits for tax paid to the work states of the earners
+	State_tax int
+	Earners   []EarnerTax
+	// set when refundable credits exceed the taxes owed and the total tax is a refund
+	Net_refund bool
+	// line by line computation of the tax metrics, only populated on request
+	Breakdown *TaxBreakdown `json:",omitempty"`
+}
+
+// taxes charged on the wages of one earner of a household
+type EarnerTax struct {
+	Income           int
+	Work_county_id   int
+	Work_county_name string
+	Work_state_id    int
+	Work_state_name  string
+	Work_locale_id   int
+	Work_locale_name string
+	// set when a reciprocity agreement between the states exempts the wages from the work state tax
+	Reciprocity        bool
+	Work_state_tax     int
+	Other_state_credit int
+	Home_locale_tax    int
+	Work_locale_tax    int
+	Payroll_tax        int
+	Total_tax          int
+}
+
+// marshaller for controller
+func (h *Household) MarshallHousehold() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(h)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,54 @@
+package model
+
+import "github.com/Matthew-Curry/re-region-api/src/apperrors"
+
+// highest income searched for an income reaching a target
+const maxSearchedIncome = 10000000
+
+// helper function to find the lowest income whose value reaches the target. The search starts from the given income,
+// doubling it until the target is reached, then bisects the incomes between. Taxes are piecewise, so where the value
+// is not increasing in income the income found is one at which the value crosses the target. Returns false if no
+// income up to the max reaches the target, and stops at the first error getting a value
+func searchIncome(target, start int, valueAt func(income int) (int, *apperrors.AppError)) (int, bool, *apperrors.AppError) {
+	value, err := valueAt(0)
+	if err != nil {
+		return 0, false, err
+	}
+	if value >= target {
+		return 0, true, nil
+	}
+
+	lo, hi := 0, minInt(start, maxSearchedIncome)
+	if hi < 1 {
+		hi = 1
+	}
+	for {
+		value, err = valueAt(hi)
+		if err != nil {
+			return 0, false, err
+		}
+		if value >= target {
+			break
+		}
+		if hi == maxSearchedIncome {
+			return 0, false, nil
+		}
+		lo, hi = hi, minInt(hi*2, maxSearchedIncome)
+	}
+
+	// the value is below the target at the low income and reaches it at the high income
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		value, err = valueAt(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if value >= target {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi, true, nil
+}
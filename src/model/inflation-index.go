@@ -0,0 +1,10 @@
+package model
+
+// indexed amounts are rounded down to a multiple of this, the way the irs rounds indexed brackets and deductions
+const indexRounding = 50
+
+// public function to index an amount by a cumulative cpi factor, rounded down to the next lowest multiple of $50
+func IndexAmount(amount int, factor float64) int {
+	indexed := int(float64(amount) * factor)
+	return indexed - indexed%indexRounding
+}
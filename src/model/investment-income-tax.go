@@ -0,0 +1,135 @@
+package model
+
+// taxable income where the zero and mid preferential rates end, and the income where the net investment income
+// tax starts, for a single filing status
+type InvestmentIncomeThresholds struct {
+	Zero_rate_threshold             int
+	Mid_rate_threshold              int
+	Net_investment_income_threshold int
+}
+
+type InvestmentIncomeTaxInfo struct {
+	// long-term gains and qualified dividends are taxed at 0, the mid rate, or the top rate depending on taxable income
+	Mid_rate float64
+	Top_rate float64
+	// net investment income tax is charged on investment income above the threshold
+	Net_investment_income_rate float64
+	Single_thresholds          InvestmentIncomeThresholds
+	Married_thresholds         InvestmentIncomeThresholds
+	Head_thresholds            InvestmentIncomeThresholds
+	Separate_thresholds        InvestmentIncomeThresholds
+	Surviving_thresholds       InvestmentIncomeThresholds
+}
+
+// constructor for InvestmentIncomeTaxInfo
+func GetInvestmentIncomeTaxInfo(mr, tr, nr float64, st, mt, ht, spt, svt InvestmentIncomeThresholds) *InvestmentIncomeTaxInfo {
+	return &InvestmentIncomeTaxInfo{
+		Mid_rate:                   mr,
+		Top_rate:                   tr,
+		Net_investment_income_rate: nr,
+		Single_thresholds:          st,
+		Married_thresholds:         mt,
+		Head_thresholds:            ht,
+		Separate_thresholds:        spt,
+		Surviving_thresholds:       svt,
+	}
+}
+
+// public method to project the investment income tax info to a later year, indexing the preferential rate thresholds
+// by the cumulative cpi factor. The net investment income tax thresholds are set by statute and not indexed
+func (i *InvestmentIncomeTaxInfo) GetProjection(factor float64) *InvestmentIncomeTaxInfo {
+	return GetInvestmentIncomeTaxInfo(i.Mid_rate, i.Top_rate, i.Net_investment_income_rate, i.Single_thresholds.getProjection(factor),
+		i.Married_thresholds.getProjection(factor), i.Head_thresholds.getProjection(factor), i.Separate_thresholds.getProjection(factor),
+		i.Surviving_thresholds.getProjection(factor))
+}
+
+// helper method to index the preferential rate thresholds of a filing status
+func (t InvestmentIncomeThresholds) getProjection(factor float64) InvestmentIncomeThresholds {
+	return InvestmentIncomeThresholds{
+		Zero_rate_threshold:             IndexAmount(t.Zero_rate_threshold, factor),
+		Mid_rate_threshold:              IndexAmount(t.Mid_rate_threshold, factor),
+		Net_investment_income_threshold: t.Net_investment_income_threshold,
+	}
+}
+
+// helper method to get the thresholds of a filing status, returns false for an unknown filing status
+func (i *InvestmentIncomeTaxInfo) getThresholds(fs FilingStatus) (InvestmentIncomeThresholds, bool) {
+	switch fs {
+	case Head:
+		return i.Head_thresholds, true
+	case Single:
+		return i.Single_thresholds, true
+	case Married:
+		return i.Married_thresholds, true
+	case MarriedSeparately:
+		return i.Separate_thresholds, true
+	case SurvivingSpouse:
+		return i.Surviving_thresholds, true
+	}
+
+	return InvestmentIncomeThresholds{}, false
+}
+
+// public method to get the tax on preferential income stacked on top of ordinary taxable income, returns the
+// total and the amount owed per preferential rate bracket
+func (i *InvestmentIncomeTaxInfo) GetPreferentialLiability(fs FilingStatus, ordinaryIncome, preferentialIncome int) (int, []BracketLiability) {
+	t, ok := i.getThresholds(fs)
+	if !ok {
+		return 0, []BracketLiability{}
+	}
+
+	bounds := []bracketBound{{bound: 0, rate: 0}, {bound: t.Zero_rate_threshold, rate: i.Mid_rate},
+		{bound: t.Mid_rate_threshold, rate: i.Top_rate}}
+
+	total := 0
+	liabilities := []BracketLiability{}
+	top := ordinaryIncome + preferentialIncome
+	for j, b := range bounds {
+		// preferential income fills the brackets from the top of ordinary income
+		lower := b.bound
+		if ordinaryIncome > lower {
+			lower = ordinaryIncome
+		}
+		upper := 0
+		higher := top
+		if j+1 < len(bounds) {
+			upper = bounds[j+1].bound
+			if upper < higher {
+				higher = upper
+			}
+		}
+
+		taxed := higher - lower
+		if taxed <= 0 {
+			continue
+		}
+
+		tax := int(float64(taxed) * b.rate)
+		total = total + tax
+		liabilities = append(liabilities, BracketLiability{
+			Rate:         b.rate,
+			Lower_bound:  b.bound,
+			Upper_bound:  upper,
+			Taxed_income: taxed,
+			Tax:          tax,
+		})
+	}
+
+	return total, liabilities
+}
+
+// public method to get the net investment income tax, charged on the smaller of investment income and the
+// adjusted gross income above the threshold
+func (i *InvestmentIncomeTaxInfo) GetNetInvestmentIncomeTax(fs FilingStatus, agi, investmentIncome int) int {
+	t, ok := i.getThresholds(fs)
+	if !ok || agi <= t.Net_investment_income_threshold {
+		return 0
+	}
+
+	taxed := minInt(investmentIncome, agi-t.Net_investment_income_threshold)
+	if taxed <= 0 {
+		return 0
+	}
+
+	return int(float64(taxed) * i.Net_investment_income_rate)
+}
@@ -0,0 +1,67 @@
+package model
+
+// deduction types the federal tax can be computed with
+const (
+	StandardDeduction = "standard"
+	ItemizedDeduction = "itemized"
+)
+
+type ItemizedDeductionInfo struct {
+	// cap on the deduction of state and local taxes, lower for married filers filing separately
+	Salt_cap          int
+	Separate_salt_cap int
+	// medical expenses are deductible above a percent of adjusted gross income
+	Medical_floor_rate float64
+	// charitable gifts are deductible up to a percent of adjusted gross income
+	Charitable_limit_rate float64
+}
+
+// constructor for ItemizedDeductionInfo
+func GetItemizedDeductionInfo(sc, ssc int, mfr, clr float64) *ItemizedDeductionInfo {
+	return &ItemizedDeductionInfo{
+		Salt_cap:              sc,
+		Separate_salt_cap:     ssc,
+		Medical_floor_rate:    mfr,
+		Charitable_limit_rate: clr,
+	}
+}
+
+// deductible amount of each itemized deduction
+type ItemizedDeductions struct {
+	// state and local income tax plus property tax, before and after the cap
+	Salt_paid         int
+	Salt              int
+	Mortgage_interest int
+	Charitable_gifts  int
+	// medical expenses above the floor
+	Medical_expenses int
+	Total            int
+}
+
+// public method to get the itemized deductions of a filer given the state and local income tax they pay
+func (i *ItemizedDeductionInfo) GetItemizedDeductions(filer FilerProfile, agi, stateLocalTax int) ItemizedDeductions {
+	if stateLocalTax < 0 {
+		stateLocalTax = 0
+	}
+
+	d := ItemizedDeductions{Salt_paid: stateLocalTax + filer.Property_tax, Mortgage_interest: filer.Mortgage_interest}
+
+	saltCap := i.Salt_cap
+	if filer.Filing_status == MarriedSeparately {
+		saltCap = i.Separate_salt_cap
+	}
+	d.Salt = minInt(d.Salt_paid, saltCap)
+
+	d.Charitable_gifts = minInt(filer.Charitable_gifts, int(float64(agi)*i.Charitable_limit_rate))
+	d.Medical_expenses = filer.Medical_expenses - int(float64(agi)*i.Medical_floor_rate)
+	if d.Charitable_gifts < 0 {
+		d.Charitable_gifts = 0
+	}
+	if d.Medical_expenses < 0 {
+		d.Medical_expenses = 0
+	}
+
+	d.Total = d.Salt + d.Mortgage_interest + d.Charitable_gifts + d.Medical_expenses
+
+	return d
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// "enum" for pay frequency request param
+type PayFrequency string
+
+const (
+	Weekly      PayFrequency = "weekly"
+	Biweekly                 = "biweekly"
+	Semimonthly              = "semimonthly"
+	Monthly                  = "monthly"
+)
+
+// empty strings default to biweekly since the param is optional
+func ToPayFrequency(s string) (PayFrequency, error) {
+	s = strings.ToLower(s)
+	if s == "" {
+		return Biweekly, nil
+	}
+	if s != "weekly" && s != "biweekly" && s != "semimonthly" && s != "monthly" {
+		return Biweekly, errors.New(fmt.Sprintf("%s is not a valid pay frequency.", s))
+	}
+
+	return PayFrequency(s), nil
+}
+
+// number of pay periods in a year, an unset frequency is paid biweekly
+func (p PayFrequency) GetPayPeriods() int {
+	switch p {
+	case Weekly:
+		return 52
+	case Semimonthly:
+		return 24
+	case Monthly:
+		return 12
+	}
+
+	return 26
+}
+
+// public function to spread an annual amount over pay periods, rounded to the cent. Negative amounts are refunds that
+// are not spread over pay periods
+func GetPerPeriodAmount(annual, periods int) float64 {
+	if annual < 0 {
+		return 0
+	}
+
+	return math.Round(float64(annual)/float64(periods)*100) / 100
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// take-home pay for each tax locale of a county
+type Paycheck struct {
+	County_id     int
+	County_name   string
+	State_id      int
+	State_name    string
+	Tax_year      int
+	Projected     bool
+	Pay_frequency PayFrequency
+	Pay_periods   int
+	Tax_locale    []PaycheckLocale
+}
+
+// amounts per paycheck, the annual estimate for the locale spread evenly over the pay periods
+type PaycheckLocale struct {
+	Locale_id   int
+	Locale_name string
+	Gross_pay   float64
+	// retirement, hsa, and section 125 contributions taken from pay before taxes
+	Pre_tax_contributions float64
+	Federal_withholding   float64
+	State_withholding     float64
+	Local_withholding     float64
+	Payroll_withholding   float64
+	Net_pay               float64
+}
+
+// marshaller for controller
+func (p *Paycheck) MarshallPaycheck() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(p)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,103 @@
+package model
+
+type PayrollTaxInfo struct {
+	// social security is charged on wages up to the wage base
+	Social_security_rate      float64
+	Social_security_wage_base int
+	// medicare is charged on all wages, with an additional rate above a threshold set by filing status
+	Medicare_rate                           float64
+	Additional_medicare_rate                float64
+	Single_additional_medicare_threshold    int
+	Married_additional_medicare_threshold   int
+	Head_additional_medicare_threshold      int
+	Separate_additional_medicare_threshold  int
+	Surviving_additional_medicare_threshold int
+}
+
+// constructor for PayrollTaxInfo
+func GetPayrollTaxInfo(ssr float64, ssb int, mr, amr float64, st, mt, ht, spt, svt int) *PayrollTaxInfo {
+	return &PayrollTaxInfo{
+		Social_security_rate:                    ssr,
+		Social_security_wage_base:               ssb,
+		Medicare_rate:                           mr,
+		Additional_medicare_rate:                amr,
+		Single_additional_medicare_threshold:    st,
+		Married_additional_medicare_threshold:   mt,
+		Head_additional_medicare_threshold:      ht,
+		Separate_additional_medicare_threshold:  spt,
+		Surviving_additional_medicare_threshold: svt,
+	}
+}
+
+// public method to project the payroll tax info to a later year, indexing the wage base by the cumulative cpi factor.
+// The additional medicare thresholds are set by statute and not indexed
+func (p *PayrollTaxInfo) GetProjection(factor float64) *PayrollTaxInfo {
+	projected := *p
+	projected.Social_security_wage_base = IndexAmount(p.Social_security_wage_base, factor)
+
+	return &projected
+}
+
+// portion of net earnings subject to self-employment tax
+const SELF_EMPLOYMENT_EARNINGS_RATE float64 = 0.9235
+
+// public method to get the payroll tax liability on wages for a filing status. The threshold share is the earner's
+// share of the wages on the return, earners filing jointly split the additional medicare threshold by their wages
+func (p *PayrollTaxInfo) GetPayrollLiability(fs FilingStatus, wages int, thresholdShare float64) PayrollTaxBreakdown {
+	return p.getLiability(fs, wages, 1, thresholdShare)
+}
+
+// public method to get the self-employment tax liability on net earnings for a filing status. The self-employed
+// pay both the employee and employer share of social security and medicare, half of which is deductible
+func (p *PayrollTaxInfo) GetSelfEmploymentLiability(fs FilingStatus, netEarnings int, thresholdShare float64) PayrollTaxBreakdown {
+	b := p.getLiability(fs, int(float64(netEarnings)*SELF_EMPLOYMENT_EARNINGS_RATE), 2, thresholdShare)
+	b.Self_employed = true
+	// the additional medicare tax is not part of the deductible portion
+	b.Deductible_portion = (b.Social_security_tax + b.Medicare_tax) / 2
+
+	return b
+}
+
+// helper method with the calculation shared by employees and the self-employed, the shares multiply the
+// social security and medicare rates to cover both the employee and employer portions
+func (p *PayrollTaxInfo) getLiability(fs FilingStatus, wages int, shares, thresholdShare float64) PayrollTaxBreakdown {
+	// use filing status to determine the additional medicare threshold
+	var threshold int
+	switch fs {
+	case Head:
+		threshold = p.Head_additional_medicare_threshold
+	case Single:
+		threshold = p.Single_additional_medicare_threshold
+	case Married:
+		threshold = p.Married_additional_medicare_threshold
+	case MarriedSeparately:
+		threshold = p.Separate_additional_medicare_threshold
+	case SurvivingSpouse:
+		threshold = p.Surviving_additional_medicare_threshold
+	default:
+		return PayrollTaxBreakdown{}
+	}
+	threshold = int(float64(threshold) * thresholdShare)
+
+	ssWages := wages
+	if ssWages > p.Social_security_wage_base {
+		ssWages = p.Social_security_wage_base
+	}
+
+	additionalWages := wages - threshold
+	if additionalWages < 0 {
+		additionalWages = 0
+	}
+
+	b := PayrollTaxBreakdown{
+		Wages:                         wages,
+		Social_security_wages:         ssWages,
+		Social_security_tax:           int(float64(ssWages) * p.Social_security_rate * shares),
+		Medicare_tax:                  int(float64(wages) * p.Medicare_rate * shares),
+		Additional_medicare_threshold: threshold,
+		Additional_medicare_tax:       int(float64(additionalWages) * p.Additional_medicare_rate),
+	}
+	b.Tax = b.Social_security_tax + b.Medicare_tax + b.Additional_medicare_tax
+
+	return b
+}
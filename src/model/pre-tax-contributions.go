@@ -0,0 +1,109 @@
+package model
+
+type ContributionLimits struct {
+	// annual limits on elective deferrals to a workplace retirement plan and traditional IRA contributions
+	Retirement_limit int
+	Ira_limit        int
+	// annual hsa limits for self-only and family coverage
+	Hsa_self_limit   int
+	Hsa_family_limit int
+}
+
+// constructor for ContributionLimits
+func GetContributionLimits(rl, il, hsl, hfl int) *ContributionLimits {
+	return &ContributionLimits{
+		Retirement_limit: rl,
+		Ira_limit:        il,
+		Hsa_self_limit:   hsl,
+		Hsa_family_limit: hfl,
+	}
+}
+
+// public method to project the contribution limits to a later year, indexing each limit by the cumulative cpi factor
+func (c *ContributionLimits) GetProjection(factor float64) *ContributionLimits {
+	return GetContributionLimits(IndexAmount(c.Retirement_limit, factor), IndexAmount(c.Ira_limit, factor),
+		IndexAmount(c.Hsa_self_limit, factor), IndexAmount(c.Hsa_family_limit, factor))
+}
+
+// pre-tax contributions allowed after applying the annual limits
+type PreTaxContributions struct {
+	Retirement  int
+	Hsa         int
+	Section_125 int
+	Ira         int
+	// contributions above the limits or the wages they are taken from, which do not reduce taxable income
+	Excess int
+}
+
+// public method to apply the annual limits to the contributions of a filer. Workplace contributions are taken from
+// wages, and the self-employed have no cafeteria plan to make section 125 contributions through
+func (c *ContributionLimits) GetPreTaxContributions(filer FilerProfile) PreTaxContributions {
+	hsaLimit := c.Hsa_self_limit
+	if filer.Hsa_family {
+		hsaLimit = c.Hsa_family_limit
+	}
+
+	var p PreTaxContributions
+	wages := filer.Income
+	if filer.Employment_type != SelfEmployed {
+		p.Section_125 = minInt(filer.Section_125_contributions, wages)
+		wages = wages - p.Section_125
+	}
+	p.Hsa = minInt(minInt(filer.Hsa_contributions, hsaLimit), wages)
+	wages = wages - p.Hsa
+	p.Retirement = minInt(minInt(filer.Retirement_contributions, c.Retirement_limit), wages)
+	// ira contributions are limited by compensation rather than what is left of it after workplace contributions
+	p.Ira = minInt(minInt(filer.Ira_contributions, c.Ira_limit), filer.Income)
+	if p.Ira < 0 {
+		p.Ira = 0
+	}
+
+	total := filer.Section_125_contributions + filer.Hsa_contributions + filer.Retirement_contributions + filer.Ira_contributions
+	p.Excess = total - p.Section_125 - p.Hsa - p.Retirement - p.Ira
+
+	return p
+}
+
+// contributions that reduce income taxed by a jurisdiction, given whether it allows retirement and hsa contributions
+func (p PreTaxContributions) GetIncomeReduction(retirementDeductible, hsaDeductible bool) int {
+	r := p.Section_125
+	if retirementDeductible {
+		r = r + p.Retirement + p.Ira
+	}
+	if hsaDeductible {
+		r = r + p.Hsa
+	}
+
+	return r
+}
+
+// contributions that reduce wages taxed by a jurisdiction, ira contributions are not taken from wages
+func (p PreTaxContributions) GetWageReduction(retirementDeductible, hsaDeductible bool) int {
+	r := p.GetIncomeReduction(retirementDeductible, hsaDeductible)
+	if retirementDeductible {
+		r = r - p.Ira
+	}
+
+	return r
+}
+
+// contributions taken out of pay, ira contributions are made outside of payroll
+func (p PreTaxContributions) GetPayDeduction() int {
+	return p.Retirement + p.Hsa + p.Section_125
+}
+
+// contributions that reduce wages subject to social security and medicare, retirement deferrals are still taxed
+func (p PreTaxContributions) GetPayrollReduction() int {
+	return p.Section_125 + p.Hsa
+}
+
+// portion of a household's contributions taken from the pay of an earner with the given share of the household wages
+func (p PreTaxContributions) GetEarnerShare(share float64) PreTaxContributions {
+	return PreTaxContributions{
+		Retirement:  int(float64(p.Retirement) * share),
+		Hsa:         int(float64(p.Hsa) * share),
+		Section_125: int(float64(p.Section_125) * share),
+		Ira:         int(float64(p.Ira) * share),
+		Excess:      int(float64(p.Excess) * share),
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+)
+
+// the income in the destination whose disposable income matches that of the origin income in the origin
+type SalaryEquivalence struct {
+	Tax_year          int
+	Projected         bool
+	Origin_income     int
+	Equivalent_income int
+	// difference from the origin income to the equivalent income, and as a percent of the origin income
+	Difference         int
+	Percent_difference *float64 `json:",omitempty"`
+	// the origin estimated at the origin income and the destination at the equivalent income
+	Origin      ComparisonRegion
+	Destination ComparisonRegion
+}
+
+// public function to find the lowest income whose disposable income reaches the target, searching from the given
+// income. Returns false if no income up to the max searched reaches the target, or the error estimating an income
+func GetEquivalentIncome(target, start int, disposableAt func(income int) (int, *apperrors.AppError)) (int, bool, *apperrors.AppError) {
+	return searchIncome(target, start, disposableAt)
+}
+
+// public function to get the salary equivalence of an origin and destination estimated at the origin and equivalent incomes
+func GetSalaryEquivalence(origin, destination ComparisonRegion, originIncome, equivalentIncome, year int, projected bool) *SalaryEquivalence {
+	difference := equivalentIncome - originIncome
+	return &SalaryEquivalence{
+		Tax_year:           year,
+		Projected:          projected,
+		Origin_income:      originIncome,
+		Equivalent_income:  equivalentIncome,
+		Difference:         difference,
+		Percent_difference: getPercentDifference(originIncome, difference),
+		Origin:             origin,
+		Destination:        destination,
+	}
+}
+
+// marshaller for controller
+func (s *SalaryEquivalence) MarshallSalaryEquivalence() ([]byte, *apperrors.AppError) {
+	r, err := json.Marshal(s)
+
+	if err != nil {
+		return nil, apperrors.UnableToMarshall(err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,58 @@
+package model
+
+// marginal share of income spent on goods and services subject to sales tax, applies to income above the bound
+type ConsumptionShare struct {
+	Income_bound int
+	Share        float64
+}
+
+// sales tax rates charged by a state, a county, and a tax locale, which add up to the combined rate
+type SalesTaxRates struct {
+	State_rate  float64
+	County_rate float64
+	Locale_rate float64
+}
+
+// estimated annual sales tax with the inputs used to compute it
+type SalesTaxBreakdown struct {
+	State_rate    float64
+	County_rate   float64
+	Locale_rate   float64
+	Combined_rate float64
+	// taxable spending is estimated from income by the consumption shares unless the filer gives their spending
+	Spending_estimated bool
+	Taxable_spending   int
+	Tax                int
+}
+
+// public function to estimate the spending subject to sales tax of a given income. Each slice of income is spent at
+// the share of the bound it falls above, so the share of income spent falls as income rises
+func GetTaxableSpending(income int, shares []ConsumptionShare) int {
+	bounds := make([]bracketBound, len(shares))
+	for i, s := range shares {
+		bounds[i] = bracketBound{bound: s.Income_bound, rate: s.Share}
+	}
+
+	spending, _ := getProgressiveLiability(income, bounds)
+	return spending
+}
+
+// public function to get the annual sales tax of the filer at the given rates. Spending is estimated from total
+// income when the filer does not give their annual spending
+func GetSalesTaxBreakdown(filer FilerProfile, rates SalesTaxRates, shares []ConsumptionShare) SalesTaxBreakdown {
+	b := SalesTaxBreakdown{
+		State_rate:       rates.State_rate,
+		County_rate:      rates.County_rate,
+		Locale_rate:      rates.Locale_rate,
+		Combined_rate:    rates.State_rate + rates.County_rate + rates.Locale_rate,
+		Taxable_spending: filer.Annual_spending,
+	}
+
+	if b.Taxable_spending == 0 {
+		b.Spending_estimated = true
+		b.Taxable_spending = GetTaxableSpending(filer.GetTotalIncome(), shares)
+	}
+	b.Tax = int(float64(b.Taxable_spending) * b.Combined_rate)
+
+	return b
+}
@@ -8,14 +8,30 @@ import (
 )
 
 type StateTaxInfo struct {
+	Tax_year   int
 	State_id   int
 	State_name string
 	// deduciton/exemption info common across the brackets
 	Single_deduction    int
 	Married_deduction   int
+	Head_deduction      int
 	Single_exemption    int
 	Married_exemption   int
+	Head_exemption      int
 	Dependent_exemption int
+	// set when the state has no separate head of household schedule, head fields hold the single schedule
+	Head_fallback bool
+	// state earned income credit as a percent of the federal credit, 0 when the state has none
+	Eitc_percent float64
+	// percent of long-term gains excluded from state taxable income, 0 when gains are taxed as ordinary income
+	Capital_gains_exclusion float64
+	// whether retirement and hsa contributions reduce state taxable income
+	Retirement_deductible bool
+	Hsa_deductible        bool
+	// whether the state indexes its brackets, deductions, and exemptions for inflation
+	Indexed bool
+	// set when the year is not yet published and the tables are projected from the latest published year
+	Projected bool
 	// list of brackets and rates
 	bracket_list []StateBracket
 }
@@ -25,41 +41,88 @@ type StateBracket struct {
 	Single_bracket  int
 	Married_rate    float64
 	Married_bracket int
+	Head_rate       float64
+	Head_bracket    int
 }
 
 // constructor for StateTaxInfo, bracket list is private to enforce ordering
-func GetStateTaxInfo(si int, sn string, sd, md, se, me, de int) *StateTaxInfo {
-	return &StateTaxInfo{State_id: si,
-		State_name:          sn,
-		Single_deduction:    sd,
-		Married_deduction:   md,
-		Single_exemption:    se,
-		Married_exemption:   me,
-		Dependent_exemption: de,
-		bracket_list:        []StateBracket{}}
+func GetStateTaxInfo(year, si int, sn string, sd, md, hd, se, me, he, de int, hf bool, ep, cge float64, rd, hsd, ind bool) *StateTaxInfo {
+	return &StateTaxInfo{Tax_year: year,
+		State_id:                si,
+		State_name:              sn,
+		Single_deduction:        sd,
+		Married_deduction:       md,
+		Head_deduction:          hd,
+		Single_exemption:        se,
+		Married_exemption:       me,
+		Head_exemption:          he,
+		Dependent_exemption:     de,
+		Head_fallback:           hf,
+		Eitc_percent:            ep,
+		Capital_gains_exclusion: cge,
+		Retirement_deductible:   rd,
+		Hsa_deductible:          hsd,
+		Indexed:                 ind,
+		bracket_list:            []StateBracket{}}
 }
 
-// public method to use private bracket list to get the single state tax liability
-func (s *StateTaxInfo) GetSingleTaxLiability(income int) int {
-	i := sort.Search(len(s.bracket_list), func(i int) bool { return s.bracket_list[i].Single_bracket >= income })
-	// subtract one if in highest bracket
-	if i == len(s.bracket_list) {
-		i = i - 1
+// public method to project the tax info to a later year. Indexed states have their brackets, deductions, and
+// exemptions indexed by the cumulative cpi factor since this year, the rest keep this year's amounts
+func (s *StateTaxInfo) GetProjection(year int, factor float64) *StateTaxInfo {
+	index := func(amount int) int {
+		if !s.Indexed {
+			return amount
+		}
+		return IndexAmount(amount, factor)
+	}
+
+	p := GetStateTaxInfo(year, s.State_id, s.State_name, index(s.Single_deduction), index(s.Married_deduction),
+		index(s.Head_deduction), index(s.Single_exemption), index(s.Married_exemption), index(s.Head_exemption),
+		index(s.Dependent_exemption), s.Head_fallback, s.Eitc_percent, s.Capital_gains_exclusion, s.Retirement_deductible,
+		s.Hsa_deductible, s.Indexed)
+	p.Projected = true
+	for _, b := range s.bracket_list {
+		p.bracket_list = append(p.bracket_list, StateBracket{
+			Single_rate:     b.Single_rate,
+			Single_bracket:  index(b.Single_bracket),
+			Married_rate:    b.Married_rate,
+			Married_bracket: index(b.Married_bracket),
+			Head_rate:       b.Head_rate,
+			Head_bracket:    index(b.Head_bracket),
+		})
 	}
-	r := s.bracket_list[i].Single_rate
 
-	return int(float64(income) * r)
+	return p
 }
 
-// public method to use private bracket list to get the state tax liability
-func (s *StateTaxInfo) GetMarriedTaxLiability(income int) int {
-	i := sort.Search(len(s.bracket_list), func(i int) bool { return s.bracket_list[i].Married_bracket >= income })
-	// subtract one if in highest bracket
-	if i == len(s.bracket_list) {
-		i = i - 1
+// public method to use private bracket list to get the single state tax liability and the amount owed per bracket
+func (s *StateTaxInfo) GetSingleTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(s.bracket_list))
+	for i, b := range s.bracket_list {
+		bounds[i] = bracketBound{bound: b.Single_bracket, rate: b.Single_rate}
 	}
-	r := s.bracket_list[i].Married_rate
-	return int(float64(income) * r)
+
+	return getProgressiveLiability(income, bounds)
+}
+
+// public method to use private bracket list to get the married state tax liability and the amount owed per bracket
+func (s *StateTaxInfo) GetMarriedTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(s.bracket_list))
+	for i, b := range s.bracket_list {
+		bounds[i] = bracketBound{bound: b.Married_bracket, rate: b.Married_rate}
+	}
+
+	return getProgressiveLiability(income, bounds)
+}
+
+// public method to use private bracket list to get the head state tax liability and the amount owed per bracket
+func (s *StateTaxInfo) GetHeadTaxLiability(income int) (int, []BracketLiability) {
+	bounds := make([]bracketBound, len(s.bracket_list))
+	for i, b := range s.bracket_list {
+		bounds[i] = bracketBound{bound: b.Head_bracket, rate: b.Head_rate}
+	}
+
+	return getProgressiveLiability(income, bounds)
 }
 
 // add pairs to the orderd list
@@ -82,23 +145,43 @@ func (s *StateTaxInfo) AppendToOrderedList(bracket StateBracket) {
 // marshaller for the controller to be able to marhsall private fields
 func (s *StateTaxInfo) MarshallStateTaxInfo() ([]byte, *apperrors.AppError) {
 	r, err := json.Marshal(struct {
-		State_id            int
-		State_name          string
-		Single_deduction    int
-		Married_deduction   int
-		Single_exemption    int
-		Married_exemption   int
-		Dependent_exemption int
-		Bracket_list        []StateBracket
+		Tax_year                int
+		State_id                int
+		State_name              string
+		Single_deduction        int
+		Married_deduction       int
+		Head_deduction          int
+		Single_exemption        int
+		Married_exemption       int
+		Head_exemption          int
+		Dependent_exemption     int
+		Head_fallback           bool
+		Eitc_percent            float64
+		Capital_gains_exclusion float64
+		Retirement_deductible   bool
+		Hsa_deductible          bool
+		Indexed                 bool
+		Projected               bool
+		Bracket_list            []StateBracket
 	}{
-		State_id:            s.State_id,
-		State_name:          s.State_name,
-		Single_deduction:    s.Single_deduction,
-		Married_deduction:   s.Married_deduction,
-		Single_exemption:    s.Single_exemption,
-		Married_exemption:   s.Married_exemption,
-		Dependent_exemption: s.Dependent_exemption,
-		Bracket_list:        s.bracket_list,
+		Tax_year:                s.Tax_year,
+		State_id:                s.State_id,
+		State_name:              s.State_name,
+		Single_deduction:        s.Single_deduction,
+		Married_deduction:       s.Married_deduction,
+		Head_deduction:          s.Head_deduction,
+		Single_exemption:        s.Single_exemption,
+		Married_exemption:       s.Married_exemption,
+		Head_exemption:          s.Head_exemption,
+		Dependent_exemption:     s.Dependent_exemption,
+		Head_fallback:           s.Head_fallback,
+		Eitc_percent:            s.Eitc_percent,
+		Capital_gains_exclusion: s.Capital_gains_exclusion,
+		Retirement_deductible:   s.Retirement_deductible,
+		Hsa_deductible:          s.Hsa_deductible,
+		Indexed:                 s.Indexed,
+		Projected:               s.Projected,
+		Bracket_list:            s.bracket_list,
 	})
 
 	if err != nil {
@@ -9,6 +9,10 @@ import (
 type State struct {
 	State_id   int
 	State_name string
+	// tax year of the brackets and deductions the estimates are made with
+	Tax_year int
+	// set when the tax year is not yet published and the tables are projected for inflation
+	Projected bool
 	// state level census metrics
 	Pop           int
 	Male_pop      int
@@ -20,6 +24,31 @@ type State struct {
 	Total_tax   int
 	State_tax   int
 	Federal_tax int
+	Payroll_tax int
+	// sales tax on the spending of the filer at the state rate, local rates vary within the state and are left out.
+	// Not part of the total tax
+	Sales_tax      int
+	Sales_tax_rate float64
+	// alternative minimum tax and net investment income tax included in the federal tax
+	Amt_tax                   int
+	Net_investment_income_tax int
+	// credits applied in the federal and state taxes
+	Child_tax_credit           int
+	Other_dependent_credit     int
+	Earned_income_credit       int
+	State_earned_income_credit int
+	// whether the federal tax took the standard or itemized deduction
+	Federal_deduction_type string
+	// set when refundable credits exceed the taxes owed and the total tax is a refund
+	Net_refund bool
+	// income left after the total tax and a year of the average rent, with the rent and tax to income ratios
+	Disposable_income DisposableIncome
+	// set when the state has no schedule for the filing status and the schedule of another status is used
+	State_schedule_fallback bool
+	// suggested estimated payments, only populated for the self-employed
+	Quarterly_estimates []EstimatedPayment `json:",omitempty"`
+	// line by line computation of the tax metrics, only populated on request
+	Breakdown *TaxBreakdown `json:",omitempty"`
 }
 
 // marshaller for controller
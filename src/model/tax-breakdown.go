@@ -0,0 +1,150 @@
+package model
+
+// line by line record of how a tax estimate was computed, returned when a caller asks for an explanation
+type TaxBreakdown struct {
+	Gross_income int
+	// pre-tax contributions after the annual limits, each level subtracts those it allows
+	Contributions PreTaxContributions
+	Federal       IncomeTaxBreakdown
+	State         IncomeTaxBreakdown
+	// not present when payroll taxes are excluded from the estimate
+	Payroll *PayrollTaxBreakdown `json:",omitempty"`
+	// only present for estimates within a tax locale
+	Locale *LocaleTaxBreakdown `json:",omitempty"`
+	// only present for households and commutes, the local and payroll taxes charged on each earner in place of
+	// the locale and payroll sections
+	Earners []EarnerTaxBreakdown `json:",omitempty"`
+	// only present for state and county estimates
+	Sales_tax *SalesTaxBreakdown `json:",omitempty"`
+}
+
+// taxes charged on the wages of one earner, resident tax where the household lives and nonresident tax where the
+// earner works
+type EarnerTaxBreakdown struct {
+	Income int
+	Locale *LocaleTaxBreakdown `json:",omitempty"`
+	// only present when the earner works across state lines without a reciprocity agreement
+	Work_state *IncomeTaxBreakdown `json:",omitempty"`
+	// the home state credit for the work state tax, included in the credits of the state section
+	Other_state_credit *TaxCredit `json:",omitempty"`
+	// only present when the earner works outside the home locale
+	Work_locale *LocaleTaxBreakdown  `json:",omitempty"`
+	Payroll     *PayrollTaxBreakdown `json:",omitempty"`
+}
+
+// inputs to the taxable income and the bracket slices hit for an income tax
+type IncomeTaxBreakdown struct {
+	// above the line adjustments subtracted before deductions
+	Adjustments int
+	// the larger of the standard and itemized deduction is taken federally, states take their standard deduction
+	Deduction_type      string
+	Itemized_deductions *ItemizedDeductions `json:",omitempty"`
+	Deduction           int
+	Personal_exemption  int
+	Dependents          int
+	Dependent_exemption int
+	Taxable_income      int
+	// ordinary taxable income by bracket
+	Brackets []BracketLiability
+	// taxable long-term gains and qualified dividends, stacked on top of ordinary income at the preferential rates
+	Preferential_income       int
+	Preferential_brackets     []BracketLiability `json:",omitempty"`
+	Net_investment_income_tax int
+	// federal only, the amount the tentative minimum tax exceeds the regular tax is added to the tax
+	Alternative_minimum_tax *AlternativeMinimumTaxBreakdown `json:",omitempty"`
+	// for nonresidents, the share of the bracket tax owed on the income sourced to the state
+	Nonresident_share float64 `json:",omitempty"`
+	// bracket tax before credits, tax is net of credits and can be negative when credits are refundable
+	Tax_before_credits int
+	Credits            []TaxCredit `json:",omitempty"`
+	Tax                int
+	// the filing status schedule applied, fallback is set when the filing status has no schedule of its own
+	Schedule          FilingStatus
+	Schedule_fallback bool
+}
+
+// rate, flat fees, and state tax piggyback applied by a local tax jurisdiction
+type LocaleTaxBreakdown struct {
+	Resident bool
+	// set when the locale does not charge its rate and fees on self-employment earnings
+	Self_employment_exempt bool
+	// earned income less the pre-tax contributions the locale allows, the rate is applied to it
+	Taxable_wages  int
+	Rate           float64
+	Rate_tax       int
+	Month_fee      float64
+	Year_fee       float64
+	Pay_period_fee float64
+	Pay_periods    int
+	Fee_tax        int
+	State_rate     float64
+	State_rate_tax int
+	Tax            int
+}
+
+// social security, medicare, and additional medicare taxes charged on wages, or on net earnings for the self-employed
+type PayrollTaxBreakdown struct {
+	Self_employed bool
+	// for the self-employed, the portion of net earnings subject to self-employment tax
+	Wages                         int
+	Social_security_wages         int
+	Social_security_tax           int
+	Medicare_tax                  int
+	Additional_medicare_threshold int
+	Additional_medicare_tax       int
+	Tax                           int
+	// half of self-employment tax is deducted from federal income
+	Deductible_portion int
+}
+
+// total tax across all levels of the breakdown
+func (t *TaxBreakdown) GetTotalTax() int {
+	total := t.Federal.Tax + t.State.Tax
+	if t.Payroll != nil {
+		total = total + t.Payroll.Tax
+	}
+	if t.Locale != nil {
+		total = total + t.Locale.Tax
+	}
+	for _, e := range t.Earners {
+		total = total + e.GetTotalTax()
+	}
+
+	return total
+}
+
+// total tax charged on the wages of an earner
+func (e *EarnerTaxBreakdown) GetTotalTax() int {
+	total := e.GetStateLocalTax()
+	if e.Payroll != nil {
+		total = total + e.Payroll.Tax
+	}
+
+	return total
+}
+
+// state and local tax charged on the wages of an earner
+func (e *EarnerTaxBreakdown) GetStateLocalTax() int {
+	total := 0
+	if e.Locale != nil {
+		total = total + e.Locale.Tax
+	}
+	if e.Work_state != nil {
+		total = total + e.Work_state.Tax
+	}
+	if e.Work_locale != nil {
+		total = total + e.Work_locale.Tax
+	}
+
+	return total
+}
+
+// portion of the annual estimate due in a quarterly estimated tax payment
+type EstimatedPayment struct {
+	Quarter        int
+	Due_date       string
+	Federal_amount int
+	State_amount   int
+	Locale_amount  int
+	Amount         int
+}
@@ -0,0 +1,151 @@
+package model
+
+// names of the credits reported in a breakdown
+const (
+	ChildTaxCredit          = "Child Tax Credit"
+	OtherDependentCredit    = "Credit for Other Dependents"
+	EarnedIncomeCredit      = "Earned Income Tax Credit"
+	StateEarnedIncomeCredit = "State Earned Income Tax Credit"
+	OtherStateCredit        = "Credit for Taxes Paid to Other States"
+)
+
+// amount of a credit applied against a tax liability
+type TaxCredit struct {
+	Name string
+	// number of people the credit is claimed for
+	Qualifying int
+	// credit before and after the income phase-out
+	Base_credit int
+	Phase_out   int
+	// portion applied against tax, and any portion paid beyond the tax owed
+	Nonrefundable int
+	Refundable    int
+	Amount        int
+}
+
+type DependentCreditInfo struct {
+	// child tax credit per qualifying child, up to the refundable amount per child can be refunded
+	Child_credit            int
+	Child_refundable_credit int
+	// refundable portion is limited to the rate applied to earned income above the threshold
+	Refundable_rate             float64
+	Refundable_income_threshold int
+	// credit for other dependents per dependent that is not a qualifying child
+	Other_dependent_credit int
+	// combined credits are reduced by the phase-out amount per phase-out step of income above the threshold
+	Phase_out_amount            int
+	Phase_out_step              int
+	Single_phase_out_threshold  int
+	Married_phase_out_threshold int
+}
+
+// constructor for DependentCreditInfo
+func GetDependentCreditInfo(cc, crc int, rr float64, rit, odc, poa, pos, spt, mpt int) *DependentCreditInfo {
+	return &DependentCreditInfo{
+		Child_credit:                cc,
+		Child_refundable_credit:     crc,
+		Refundable_rate:             rr,
+		Refundable_income_threshold: rit,
+		Other_dependent_credit:      odc,
+		Phase_out_amount:            poa,
+		Phase_out_step:              pos,
+		Single_phase_out_threshold:  spt,
+		Married_phase_out_threshold: mpt,
+	}
+}
+
+// public method to project the dependent credits to a later year. Only the refundable portion of the child credit is
+// indexed by the cumulative cpi factor, up to the full credit
+func (d *DependentCreditInfo) GetProjection(factor float64) *DependentCreditInfo {
+	projected := *d
+	projected.Child_refundable_credit = minInt(IndexAmount(d.Child_refundable_credit, factor), d.Child_credit)
+
+	return &projected
+}
+
+// public method to get the child tax credit and credit for other dependents applied against the given tax. Returns
+// the child tax credit followed by the credit for other dependents
+func (d *DependentCreditInfo) GetDependentCredits(fs FilingStatus, agi, earnedIncome, tax, children, others int) (TaxCredit, TaxCredit) {
+	ctc := TaxCredit{Name: ChildTaxCredit, Qualifying: children, Base_credit: children * d.Child_credit}
+	odc := TaxCredit{Name: OtherDependentCredit, Qualifying: others, Base_credit: others * d.Other_dependent_credit}
+
+	// only married joint filers have the higher phase-out threshold
+	var threshold int
+	switch fs {
+	case Married:
+		threshold = d.Married_phase_out_threshold
+	case Single, Head, MarriedSeparately, SurvivingSpouse:
+		threshold = d.Single_phase_out_threshold
+	default:
+		return ctc, odc
+	}
+
+	// the phase-out applies for each step or fraction of a step above the threshold, reducing the child credit first
+	reduction := 0
+	if agi > threshold {
+		reduction = (agi - threshold + d.Phase_out_step - 1) / d.Phase_out_step * d.Phase_out_amount
+	}
+	ctc.Phase_out = ctc.Base_credit - reduction
+	if ctc.Phase_out < 0 {
+		reduction = -ctc.Phase_out
+		ctc.Phase_out = 0
+	} else {
+		reduction = 0
+	}
+	odc.Phase_out = odc.Base_credit - reduction
+	if odc.Phase_out < 0 {
+		odc.Phase_out = 0
+	}
+
+	// the credit for other dependents is applied against the tax first
+	odc.Nonrefundable = minInt(odc.Phase_out, tax)
+	ctc.Nonrefundable = minInt(ctc.Phase_out, tax-odc.Nonrefundable)
+
+	// the unused child credit is refundable up to the per child amount and the earned income limit
+	earnedLimit := int(float64(earnedIncome-d.Refundable_income_threshold) * d.Refundable_rate)
+	ctc.Refundable = minInt(minInt(ctc.Phase_out-ctc.Nonrefundable, children*d.Child_refundable_credit), earnedLimit)
+	if ctc.Refundable < 0 {
+		ctc.Refundable = 0
+	}
+
+	odc.Amount = odc.Nonrefundable
+	ctc.Amount = ctc.Nonrefundable + ctc.Refundable
+
+	return ctc, odc
+}
+
+// public function to combine the credits of the same name claimed by each earner of a household into one credit
+func CombineCredits(name string, credits []TaxCredit) TaxCredit {
+	combined := TaxCredit{Name: name}
+	for _, c := range credits {
+		combined.Qualifying = combined.Qualifying + c.Qualifying
+		combined.Base_credit = combined.Base_credit + c.Base_credit
+		combined.Phase_out = combined.Phase_out + c.Phase_out
+		combined.Nonrefundable = combined.Nonrefundable + c.Nonrefundable
+		combined.Refundable = combined.Refundable + c.Refundable
+		combined.Amount = combined.Amount + c.Amount
+	}
+
+	return combined
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// public function to get the home state credit for tax paid to another state. The credit is limited to the home state
+// tax on the share of income also taxed by the other state, and is not refundable
+func GetOtherStateCredit(otherStateTax, tax int, share float64) TaxCredit {
+	credit := TaxCredit{Name: OtherStateCredit, Qualifying: 1, Base_credit: otherStateTax}
+	credit.Phase_out = minInt(otherStateTax, int(float64(tax)*share))
+	if credit.Phase_out < 0 {
+		credit.Phase_out = 0
+	}
+	credit.Nonrefundable = credit.Phase_out
+	credit.Amount = credit.Phase_out
+
+	return credit
+}
@@ -30,3 +30,8 @@ func readAsFloat(i interface{}) float64 {
 
 	return f
 }
+
+// return var as bool
+func readAsBool(i interface{}) bool {
+	return i.(bool)
+}
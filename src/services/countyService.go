@@ -8,11 +8,28 @@ import (
 )
 
 type CountyServiceInterface interface {
-	// public methods to request a County
-	GetCountyById(id int, fs model.FilingStatus, resident bool, dependents int, income int) (*model.County, *apperrors.AppError)
-	GetCountyByName(name string, fs model.FilingStatus, resident bool, dependents int, income int) (*model.County, *apperrors.AppError)
+	// public methods to request a County, optionally explaining the computation of the tax estimates
+	GetCountyById(id int, filer model.FilerProfile, explain bool) (*model.County, *apperrors.AppError)
+	GetCountyByName(name string, filer model.FilerProfile, explain bool) (*model.County, *apperrors.AppError)
+	// public methods to request the take-home pay per paycheck in each tax locale of a County
+	GetPaycheckById(id int, filer model.FilerProfile) (*model.Paycheck, *apperrors.AppError)
+	GetPaycheckByName(name string, filer model.FilerProfile) (*model.Paycheck, *apperrors.AppError)
+	// public methods to request the gross income needed in a tax locale of a County for the filer to take home a target
+	// net income, in the first tax locale unless one is given
+	GetGrossUpById(id, localeId int, localeGiven bool, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError)
+	GetGrossUpByName(name string, localeId int, localeGiven bool, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError)
+	// public methods to request the lowest income in a County whose disposable income for the filer reaches that of an
+	// origin region, in the first tax locale unless one is given
+	GetSalaryEquivalenceById(id, localeId int, localeGiven bool, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError)
+	GetSalaryEquivalenceByName(name string, localeId int, localeGiven bool, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError)
+	// public method to request the taxes of a commute from a home County to a work County, given the tax info of each
+	GetCommute(home, work *model.CountyTaxList, filer model.FilerProfile, explain bool) (*model.Commute, *apperrors.AppError)
+	// public method to request the taxes of a household living in a County, given the wages and work County of each earner
+	GetHousehold(home *model.CountyTaxList, earners []model.EarnerProfile, filer model.FilerProfile, explain bool) (*model.Household, *apperrors.AppError)
 	// public method to request County list by metric name and size
 	GetCountyList(metricName string, n int, desc bool) (*model.CountyList, *apperrors.AppError)
+	// public method to request County list by a metric computed for the filer in every County, such as the total tax
+	GetComputedCountyList(metricName string, n int, desc bool, filer model.FilerProfile) (*model.ComputedCountyList, *apperrors.AppError)
 	// public methods to request the tax info for a County
 	GetCountyTaxListById(id int) (*model.CountyTaxList, *apperrors.AppError)
 	GetCountyTaxListByName(name string) (*model.CountyTaxList, *apperrors.AppError)
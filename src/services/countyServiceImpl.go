@@ -7,7 +7,9 @@ import (
 	"github.com/Matthew-Curry/re-region-api/src/dao"
 	"github.com/Matthew-Curry/re-region-api/src/model"
 
+	"math"
 	"strings"
+	"sync"
 )
 
 // for core county response
@@ -21,6 +23,8 @@ const (
 	COUNTY_MEDIAN_INCOME
 	COUNTY_AVERAGE_RENT
 	COUNTY_COMMUTE
+	COUNTY_PROPERTY_TAX_RATE
+	COUNTY_MEDIAN_HOME_VALUE
 	COUNTY_TAX_JURISDICTION_ID
 	COUNTY_TAX_JURISDICTION_NAME
 	COUNTY_RESIDENT_DESC
@@ -35,8 +39,14 @@ const (
 	COUNTY_NONRESIDENT_YEAR_FEE
 	COUNTY_NONRESIDENT_PAY_PERIOD_FEE
 	COUNTY_NONRESIDENT_STATE_RATE
+	COUNTY_SELF_EMPLOYMENT_TAXED
+	COUNTY_RETIREMENT_DEDUCTIBLE
+	COUNTY_HSA_DEDUCTIBLE
 )
 
+// computed county lists kept before the cache is cleared, lists are cached for each metric and set of filer variables
+const COMPUTED_LIST_CACHE_SIZE = 256
+
 // for the county list response
 const (
 	COUNTY_LIST_ID = iota
@@ -55,12 +65,31 @@ type CountyServiceImpl struct {
 	countyTaxNameMp map[string]*model.CountyTaxList
 	countyTaxIdMp   map[int]*model.CountyTaxList
 
+	// every county with its tax info, read once when first ranking by a computed metric
+	allCounties     []cachedCounty
+	allCountiesLock sync.Mutex
+	// the metric of every county computed for a filer, by metric and filer variables including the tax year
+	computedLists     map[computedListKey][]model.ComputedCountyMetricPair
+	computedListsLock sync.Mutex
+
 	// use provided impl of state service to access state + federal tax information
 	stateService StateServiceInterface
 	// use provided implementation of dao service to make requests to the database
 	daoImpl dao.DaoInterface
 }
 
+// a county with an empty tax locale and the tax info its taxes are estimated from
+type cachedCounty struct {
+	county  *model.County
+	taxList *model.CountyTaxList
+}
+
+// a computed metric and the filer variables it is computed for
+type computedListKey struct {
+	metric string
+	filer  model.FilerProfile
+}
+
 // constructor to return this implementation of the county service
 func GetCountyServiceImpl(daoImpl dao.DaoInterface, stateService StateServiceInterface) (CountyServiceInterface, *apperrors.AppError) {
 	// initialize implementation with empty caches. Caches will be populated as records are requested
@@ -68,11 +97,19 @@ func GetCountyServiceImpl(daoImpl dao.DaoInterface, stateService StateServiceInt
 		countyNameMp:    map[string]*model.County{},
 		countyTaxNameMp: map[string]*model.CountyTaxList{},
 		countyTaxIdMp:   map[int]*model.CountyTaxList{},
+		computedLists:   map[computedListKey][]model.ComputedCountyMetricPair{},
 		stateService:    stateService,
 		daoImpl:         daoImpl}, nil
 }
 
-func (c *CountyServiceImpl) GetCountyById(id int, fs model.FilingStatus, resident bool, dependents int, income int) (*model.County, *apperrors.AppError) {
+func (c *CountyServiceImpl) GetCountyById(id int, filer model.FilerProfile, explain bool) (*model.County, *apperrors.AppError) {
+	// resolve the tax year the estimates are made for
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if id in map, if not get from db
 	county, ok := c.countyIdMp[id]
 	if ok {
@@ -80,7 +117,7 @@ func (c *CountyServiceImpl) GetCountyById(id int, fs model.FilingStatus, residen
 		logger.Info("County %v found in cache", id)
 		countyTaxInfo := c.countyTaxIdMp[id]
 
-		return c.appendLocalTaxToCounty(county, countyTaxInfo, fs, resident, dependents, income), nil
+		return c.appendLocalTaxToCounty(county, countyTaxInfo, filer, explain)
 	}
 	logger.Info("County %v not found in cache, querying data access layer", id)
 	countyData, err := c.daoImpl.GetCountyDataById(id)
@@ -90,13 +127,38 @@ func (c *CountyServiceImpl) GetCountyById(id int, fs model.FilingStatus, residen
 
 	// place the data in the maps and return the county
 	logger.Info("Recieved response, placing data into the appropriate caches")
-	county, _, _ = c.placeCountyDataInMaps(countyData, fs, resident, dependents, income)
+	county, countyTaxInfo, err := c.placeCountyDataInMaps(countyData)
+	if err != nil {
+		return nil, err
+	}
 
-	return county, nil
+	return c.appendLocalTaxToCounty(county, countyTaxInfo, filer, explain)
 }
 
-// helper method with core logic to update caches and return responses
-func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}, fs model.FilingStatus, resident bool, dependents int, income int) (*model.County, *model.CountyTaxList, *apperrors.AppError) {
+// helper method with core logic to update caches, returns the cached county and tax info. Taxes are estimated from the
+// cached county by request
+func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}) (*model.County, *model.CountyTaxList, *apperrors.AppError) {
+	county, taxList, err := c.readCountyData(countyData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// lowercase and trim the county name for the maps
+	lowerCountyName := strings.TrimSpace(strings.ToLower(county.County_name))
+
+	c.countyTaxIdMp[county.County_id] = taxList
+	c.countyTaxNameMp[lowerCountyName] = taxList
+
+	// cache the county information with an empty tax local, will use tax info + request info to calculate tax attributes when request arrives
+	c.countyIdMp[county.County_id] = county
+	c.countyNameMp[lowerCountyName] = county
+
+	return county, taxList, nil
+}
+
+// helper method to read the rows of a county into the county information and tax info that are cached, without
+// estimating any taxes
+func (c *CountyServiceImpl) readCountyData(countyData [][]interface{}) (*model.County, *model.CountyTaxList, *apperrors.AppError) {
 	// county name and id
 	countyName := readAsString(countyData[0][COUNTY_NAME])
 	countyId := readAsInt(countyData[0][COUNTY_ID])
@@ -109,7 +171,6 @@ func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}, fs
 
 	// process the local tax info for each row
 	var taxLocaleInfos []model.TaxLocaleInfo
-	var taxLocales []model.TaxLocale
 	for _, row := range countyData {
 		// attributes of the locality read in from the row
 		tli := readAsInt(row[COUNTY_TAX_JURISDICTION_ID])
@@ -126,11 +187,12 @@ func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}, fs
 		nonResYearFee := readAsFloat(row[COUNTY_NONRESIDENT_YEAR_FEE])
 		nonResPayPeriod := readAsFloat(row[COUNTY_NONRESIDENT_PAY_PERIOD_FEE])
 		nonResStateRate := readAsFloat(row[COUNTY_NONRESIDENT_STATE_RATE])
+		seTaxed := readAsBool(row[COUNTY_SELF_EMPLOYMENT_TAXED])
+		retirementDeductible := readAsBool(row[COUNTY_RETIREMENT_DEDUCTIBLE])
+		hsaDeductible := readAsBool(row[COUNTY_HSA_DEDUCTIBLE])
 
-		// the different levels of tax liability to populate
-		var tl, fl, sl, ll int
 		// append static info for a locality
-		taxLocaleInfos = append(taxLocaleInfos, model.TaxLocaleInfo{
+		taxLocaleInfo := model.TaxLocaleInfo{
 			Locale_id:                  tli,
 			Local_name:                 tln,
 			Resident_desc:              resDesc,
@@ -145,31 +207,13 @@ func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}, fs
 			Nonresident_year_fee:       nonResYearFee,
 			Nonresident_pay_period_fee: nonResPayPeriod,
 			Nonresident_state_rate:     nonResStateRate,
-		})
-
-		// process tax liabilities for the given parameters
-		if resident {
-			logger.Info("Getting resident tax liability")
-			tl, fl, sl, ll = c.getTaxLiability(tli, tln, stateId, fs, dependents, income, resStateRate,
-				resMonthFee, resYearFee, resPayPeriod, resStateRate)
-		} else {
-			logger.Info("Getting non-resident tax liability")
-			tl, fl, sl, ll = c.getTaxLiability(tli, tln, stateId, fs, dependents, income, nonResStateRate,
-				nonResMonthFee, nonResYearFee, nonResPayPeriod, nonResStateRate)
+			Self_employment_taxed:      seTaxed,
+			Retirement_deductible:      retirementDeductible,
+			Hsa_deductible:             hsaDeductible,
 		}
-
-		// append the formed tax locale
-		taxLocales = append(taxLocales, model.TaxLocale{
-			Locale_id:   tli,
-			Locale_name: tln,
-			Total_tax:   tl,
-			Federal_tax: fl,
-			State_tax:   sl,
-			Locale_tax:  ll,
-		})
+		taxLocaleInfos = append(taxLocaleInfos, taxLocaleInfo)
 	}
 
-	// append to maps an return the county and tax list
 	taxList := &model.CountyTaxList{
 		County_name: countyName,
 		County_id:   countyId,
@@ -178,31 +222,68 @@ func (c *CountyServiceImpl) placeCountyDataInMaps(countyData [][]interface{}, fs
 		Tax_locales: taxLocaleInfos,
 	}
 
-	// lowercase and trim the county name for the maps
-	lowerCountyName := strings.TrimSpace(strings.ToLower(countyName))
+	return c.buildCounty(countyId, countyName, stateId, stateName, countyData[0], []model.TaxLocale{}), taxList, nil
+}
 
-	c.countyTaxIdMp[countyId] = taxList
-	c.countyTaxNameMp[lowerCountyName] = taxList
+// helper method to get the local tax liability on top of the state and federal liability for the locale, along
+// with the sales tax at the combined rate of the state, county, and locale
+func (c *CountyServiceImpl) getTaxLiability(stateId, countyId int, filer model.FilerProfile, taxLocale model.TaxLocaleInfo) (*model.TaxBreakdown, *apperrors.AppError) {
+	b, err := c.stateService.processTaxLiabilityById(stateId, filer, &taxLocale)
+	if err != nil {
+		return nil, err
+	}
+	salesTax := c.stateService.getSalesTax(filer, stateId, countyId, taxLocale.Locale_id)
+	b.Sales_tax = &salesTax
 
-	respCounty := c.buildCounty(countyId, countyName, stateId, stateName, countyData[0], taxLocales)
-	// cache the county information with an empty tax local, will use tax info + request info to calculate tax attributes when request arrives
-	cacheCounty := c.buildCounty(countyId, countyName, stateId, stateName, countyData[0], []model.TaxLocale{})
+	return b, nil
+}
 
-	c.countyIdMp[countyId] = cacheCounty
-	c.countyNameMp[lowerCountyName] = cacheCounty
+// helper method to build a tax locale from its computed breakdown and the average rent of the county, the breakdown is
+// only attached if an explanation is requested
+func (c *CountyServiceImpl) buildTaxLocale(taxLocale model.TaxLocaleInfo, filer model.FilerProfile, b *model.TaxBreakdown, averageRent int, explain bool) model.TaxLocale {
+	tl := model.TaxLocale{
+		Locale_id:   taxLocale.Locale_id,
+		Locale_name: taxLocale.Local_name,
+		Total_tax:   b.GetTotalTax(),
+		Federal_tax: b.Federal.Tax,
+		State_tax:   b.State.Tax,
+		Locale_tax:  b.Locale.Tax,
+		// report when the state schedule for the filing status is not available
+		State_schedule_fallback: b.State.Schedule_fallback,
+	}
 
-	return respCounty, taxList, nil
+	if b.Payroll != nil {
+		tl.Payroll_tax = b.Payroll.Tax
+	}
 
-}
+	if b.Sales_tax != nil {
+		tl.Sales_tax = b.Sales_tax.Tax
+		tl.Sales_tax_rate = b.Sales_tax.Combined_rate
+	}
+
+	tl.Child_tax_credit = getCredit(b.Federal, model.ChildTaxCredit).Amount
+	tl.Other_dependent_credit = getCredit(b.Federal, model.OtherDependentCredit).Amount
+	tl.Earned_income_credit = getCredit(b.Federal, model.EarnedIncomeCredit).Amount
+	tl.State_earned_income_credit = getCredit(b.State, model.StateEarnedIncomeCredit).Amount
+	tl.Federal_deduction_type = b.Federal.Deduction_type
+	tl.Net_investment_income_tax = b.Federal.Net_investment_income_tax
+	if b.Federal.Alternative_minimum_tax != nil {
+		tl.Amt_tax = b.Federal.Alternative_minimum_tax.Tax
+	}
+	// refundable credits can exceed the taxes owed
+	tl.Net_refund = tl.Total_tax < 0
+	tl.Disposable_income = model.GetDisposableIncome(b.Gross_income, tl.Total_tax, b.Contributions.GetPayDeduction(), averageRent)
 
-// helper method to get the local tax liability
-func (c *CountyServiceImpl) getTaxLiability(tli int, tln string, stateId int, fs model.FilingStatus, dep int, income int, rate, monthFee, yearFee, payPeriodFee, stateRate float64) (int, int, int, int) {
-	tl, sl, fl := c.stateService.processTaxLiabilityById(stateId, fs, dep, income)
-	logger.Info("Getting county liability")
-	ll := int(float64(income)*rate) + int(12*monthFee) + int(yearFee) + int(payPeriodFee*26) + sl*int(stateRate)
-	tl = tl + ll
+	// the self-employed pay estimated taxes through the year instead of withholding
+	if filer.Employment_type == model.SelfEmployed {
+		tl.Quarterly_estimates = getQuarterlyEstimates(b)
+	}
 
-	return tl, fl, sl, ll
+	if explain {
+		tl.Breakdown = b
+	}
+
+	return tl
 }
 
 // helper method to build a county
@@ -218,29 +299,56 @@ func (c *CountyServiceImpl) buildCounty(countyId int, countyName string, stateId
 		Median_income: readAsInt(countyDataRow[COUNTY_MEDIAN_INCOME]),
 		Average_rent:  readAsInt(countyDataRow[COUNTY_AVERAGE_RENT]),
 		Commute:       readAsInt(countyDataRow[COUNTY_COMMUTE]),
-		Tax_locale:    taxLocales,
+		// property tax metrics
+		Median_home_value: readAsInt(countyDataRow[COUNTY_MEDIAN_HOME_VALUE]),
+		Property_tax_rate: readAsFloat(countyDataRow[COUNTY_PROPERTY_TAX_RATE]),
+		Tax_locale:        taxLocales,
 	}
 }
 
+// helper function to estimate the annual property tax of the filer at the effective rate of the county, on the county
+// median home value when the filer does not give theirs. Returns the home value and the estimate. When requested, the
+// estimate is deducted as the property tax paid of a filer who does not give the property tax they pay
+func estimatePropertyTax(county *model.County, filer model.FilerProfile) (int, int, model.FilerProfile) {
+	homeValue := filer.Home_value
+	if homeValue == 0 {
+		homeValue = county.Median_home_value
+	}
+	propertyTax := model.GetPropertyTax(homeValue, county.Property_tax_rate)
+
+	if filer.Deduct_property_tax && filer.Property_tax == 0 {
+		filer.Property_tax = propertyTax
+	}
+
+	return homeValue, propertyTax, filer
+}
+
 // logic to populate tax locales for a given county, tax information, and inputs to tax calculation
-func (c *CountyServiceImpl) appendLocalTaxToCounty(county *model.County, countyTaxInfo *model.CountyTaxList, fs model.FilingStatus, resident bool, dependents int, income int) *model.County {
+func (c *CountyServiceImpl) appendLocalTaxToCounty(county *model.County, countyTaxInfo *model.CountyTaxList, filer model.FilerProfile, explain bool) (*model.County, *apperrors.AppError) {
+	// copy the cached county so the tax locales of this request are not appended to the cache
+	respCounty := *county
+	respCounty.Tax_year = filer.Tax_year
+	respCounty.Projected = c.stateService.isProjected(filer.Tax_year)
+	respCounty.Home_value, respCounty.Property_tax, filer = estimatePropertyTax(county, filer)
+	respCounty.Tax_locale = []model.TaxLocale{}
 	for _, taxLocale := range countyTaxInfo.Tax_locales {
-		tl, fl, sl, ll := c.getTaxLiability(taxLocale.Locale_id, taxLocale.Local_name, county.State_id, fs, dependents, income, taxLocale.Resident_rate,
-			taxLocale.Resident_month_fee, taxLocale.Resident_year_fee, taxLocale.Resident_pay_period_fee, taxLocale.Resident_state_rate)
-
-		county.Tax_locale = append(county.Tax_locale, model.TaxLocale{
-			Locale_id:   taxLocale.Locale_id,
-			Locale_name: taxLocale.Local_name,
-			Total_tax:   tl,
-			Federal_tax: fl,
-			State_tax:   sl,
-			Locale_tax:  ll,
-		})
+		b, err := c.getTaxLiability(county.State_id, county.County_id, filer, taxLocale)
+		if err != nil {
+			return nil, err
+		}
+		respCounty.Tax_locale = append(respCounty.Tax_locale, c.buildTaxLocale(taxLocale, filer, b, county.Average_rent, explain))
 	}
-	return county
+	return &respCounty, nil
 }
 
-func (c *CountyServiceImpl) GetCountyByName(name string, fs model.FilingStatus, resident bool, dependents int, income int) (*model.County, *apperrors.AppError) {
+func (c *CountyServiceImpl) GetCountyByName(name string, filer model.FilerProfile, explain bool) (*model.County, *apperrors.AppError) {
+	// resolve the tax year the estimates are made for
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if name in map, if not get from db
 	name = formatCountyInput(name)
 	county, ok := c.countyNameMp[name]
@@ -249,7 +357,7 @@ func (c *CountyServiceImpl) GetCountyByName(name string, fs model.FilingStatus,
 		logger.Info("County %s found in cache", name)
 		countyTaxInfo := c.countyTaxNameMp[name]
 
-		return c.appendLocalTaxToCounty(county, countyTaxInfo, fs, resident, dependents, income), nil
+		return c.appendLocalTaxToCounty(county, countyTaxInfo, filer, explain)
 	}
 	logger.Info("County %s not found in cache, querying data access layer", name)
 	countyData, err := c.daoImpl.GetCountyDataByName(name)
@@ -259,9 +367,342 @@ func (c *CountyServiceImpl) GetCountyByName(name string, fs model.FilingStatus,
 
 	// place the data in the maps and return the county
 	logger.Info("Recieved response, placing data into the appropriate caches")
-	county, _, _ = c.placeCountyDataInMaps(countyData, fs, resident, dependents, income)
+	county, countyTaxInfo, err := c.placeCountyDataInMaps(countyData)
+	if err != nil {
+		return nil, err
+	}
 
-	return county, nil
+	return c.appendLocalTaxToCounty(county, countyTaxInfo, filer, explain)
+}
+
+// get the paycheck for each tax locale of a county by id
+func (c *CountyServiceImpl) GetPaycheckById(id int, filer model.FilerProfile) (*model.Paycheck, *apperrors.AppError) {
+	// the breakdown of each tax locale holds the annual amounts to spread over the paychecks
+	county, err := c.GetCountyById(id, filer, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPaycheck(county, filer), nil
+}
+
+// get the paycheck for each tax locale of a county by name
+func (c *CountyServiceImpl) GetPaycheckByName(name string, filer model.FilerProfile) (*model.Paycheck, *apperrors.AppError) {
+	county, err := c.GetCountyByName(name, filer, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPaycheck(county, filer), nil
+}
+
+// get the gross income needed in a tax locale of a county by id for the filer to take home a target net income.
+// Returns false if no income up to the max searched reaches the target
+func (c *CountyServiceImpl) GetGrossUpById(id, localeId int, localeGiven bool, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the gross incomes of county %v for a net income of %v", id, target)
+	return getGrossUp(target, tolerance, filer, c.stateService.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			county, err := c.GetCountyById(id, filer, true)
+			if err != nil {
+				return model.ComparisonRegion{}, nil, err
+			}
+			return getCountySearchRegion(county, localeId, localeGiven)
+		})
+}
+
+// get the gross income needed in a tax locale of a county by name for the filer to take home a target net income
+func (c *CountyServiceImpl) GetGrossUpByName(name string, localeId int, localeGiven bool, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the gross incomes of %s for a net income of %v", name, target)
+	return getGrossUp(target, tolerance, filer, c.stateService.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			county, err := c.GetCountyByName(name, filer, true)
+			if err != nil {
+				return model.ComparisonRegion{}, nil, err
+			}
+			return getCountySearchRegion(county, localeId, localeGiven)
+		})
+}
+
+// get the lowest income in a tax locale of a county by id whose disposable income for the filer reaches that of the
+// origin region, estimated at the income of the filer. Returns false if no income up to the max searched reaches it
+func (c *CountyServiceImpl) GetSalaryEquivalenceById(id, localeId int, localeGiven bool, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the incomes of county %v for a disposable income of %v", id, origin.Disposable_income)
+	return getSalaryEquivalence(origin, filer, c.stateService.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			county, err := c.GetCountyById(id, filer, true)
+			if err != nil {
+				return model.ComparisonRegion{}, nil, err
+			}
+			return getCountySearchRegion(county, localeId, localeGiven)
+		})
+}
+
+// get the lowest income in a tax locale of a county by name whose disposable income for the filer reaches that of the
+// origin region
+func (c *CountyServiceImpl) GetSalaryEquivalenceByName(name string, localeId int, localeGiven bool, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the incomes of %s for a disposable income of %v", name, origin.Disposable_income)
+	return getSalaryEquivalence(origin, filer, c.stateService.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			county, err := c.GetCountyByName(name, filer, true)
+			if err != nil {
+				return model.ComparisonRegion{}, nil, err
+			}
+			return getCountySearchRegion(county, localeId, localeGiven)
+		})
+}
+
+// helper function to reduce a county estimated with a breakdown to the region of the tax locale returned with a gross
+// up or salary equivalence, the first tax locale unless one is given
+func getCountySearchRegion(county *model.County, localeId int, localeGiven bool) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+	if !localeGiven {
+		if len(county.Tax_locale) == 0 {
+			return model.GetDefaultCountyComparisonRegion(county), nil, nil
+		}
+		localeId = county.Tax_locale[0].Locale_id
+	}
+
+	for _, tl := range county.Tax_locale {
+		if tl.Locale_id == localeId {
+			region, _ := model.GetCountyComparisonRegion(county, localeId)
+			return region, tl.Breakdown, nil
+		}
+	}
+
+	return model.ComparisonRegion{}, nil, apperrors.TaxLocaleNotFound(localeId, county.County_name)
+}
+
+// helper function to spread the annual estimate of each tax locale over the pay periods of the filer. Refunds
+// are claimed when filing, so withholding is never negative
+func buildPaycheck(county *model.County, filer model.FilerProfile) *model.Paycheck {
+	periods := filer.Pay_frequency.GetPayPeriods()
+	paycheck := &model.Paycheck{
+		County_id:     county.County_id,
+		County_name:   county.County_name,
+		State_id:      county.State_id,
+		State_name:    county.State_name,
+		Tax_year:      county.Tax_year,
+		Projected:     county.Projected,
+		Pay_frequency: filer.Pay_frequency,
+		Pay_periods:   periods,
+		Tax_locale:    []model.PaycheckLocale{},
+	}
+
+	for _, tl := range county.Tax_locale {
+		contributions := tl.Breakdown.Contributions
+		pl := model.PaycheckLocale{
+			Locale_id:             tl.Locale_id,
+			Locale_name:           tl.Locale_name,
+			Gross_pay:             model.GetPerPeriodAmount(filer.Income, periods),
+			Pre_tax_contributions: model.GetPerPeriodAmount(contributions.GetPayDeduction(), periods),
+			Federal_withholding:   model.GetPerPeriodAmount(tl.Federal_tax, periods),
+			State_withholding:     model.GetPerPeriodAmount(tl.State_tax, periods),
+			Local_withholding:     model.GetPerPeriodAmount(tl.Locale_tax, periods),
+			Payroll_withholding:   model.GetPerPeriodAmount(tl.Payroll_tax, periods),
+		}
+		pl.Net_pay = math.Round((pl.Gross_pay-pl.Pre_tax_contributions-pl.Federal_withholding-pl.State_withholding-
+			pl.Local_withholding-pl.Payroll_withholding)*100) / 100
+		paycheck.Tax_locale = append(paycheck.Tax_locale, pl)
+	}
+
+	return paycheck
+}
+
+// helper method to resolve the tax year of a commute or household, the controller has already validated the year
+// so a year that is not loaded falls back to the default year
+func (c *CountyServiceImpl) resolveTaxYear(year int) int {
+	resolved, err := c.stateService.getTaxYear(year)
+	if err != nil {
+		resolved, _ = c.stateService.getTaxYear(0)
+	}
+
+	return resolved
+}
+
+// get the taxes of a filer who lives in the home county and works in the work county, with an estimate for
+// each pair of tax locales in the two counties
+func (c *CountyServiceImpl) GetCommute(home, work *model.CountyTaxList, filer model.FilerProfile, explain bool) (*model.Commute, *apperrors.AppError) {
+	filer.Tax_year = c.resolveTaxYear(filer.Tax_year)
+	commute := &model.Commute{
+		Home_county_id:   home.County_id,
+		Home_county_name: home.County_name,
+		Home_state_id:    home.State_id,
+		Home_state_name:  home.State_name,
+		Work_county_id:   work.County_id,
+		Work_county_name: work.County_name,
+		Work_state_id:    work.State_id,
+		Work_state_name:  work.State_name,
+		Tax_year:         filer.Tax_year,
+		Projected:        c.stateService.isProjected(filer.Tax_year),
+		Tax_locale:       []model.CommuteLocale{},
+	}
+	commute.Reciprocity = work.State_id != home.State_id && c.stateService.hasReciprocity(home.State_id, work.State_id, filer)
+
+	// a commuter is a household with one earner, who is a resident where they live
+	filer.Resident = true
+	for _, homeLocale := range home.Tax_locales {
+		for _, workLocale := range work.Tax_locales {
+			homeLocale := homeLocale
+			earners := []model.EarnerWork{{Income: filer.Income, Work_state_id: work.State_id, Work_locale: workLocale}}
+			b, err := c.stateService.processHouseholdTaxLiabilityById(home.State_id, filer, &homeLocale, earners)
+			if err != nil {
+				return nil, err
+			}
+			commute.Tax_locale = append(commute.Tax_locale, buildCommuteLocale(homeLocale, workLocale, b, explain))
+		}
+	}
+
+	return commute, nil
+}
+
+// helper function to build the taxes for a pair of home and work tax locales from the computed breakdown, the
+// breakdown is only attached if an explanation is requested
+func buildCommuteLocale(homeLocale, workLocale model.TaxLocaleInfo, b *model.TaxBreakdown, explain bool) model.CommuteLocale {
+	et := buildEarnerTax(b.Earners[0])
+	cl := model.CommuteLocale{
+		Home_locale_id:     homeLocale.Locale_id,
+		Home_locale_name:   homeLocale.Local_name,
+		Work_locale_id:     workLocale.Locale_id,
+		Work_locale_name:   workLocale.Local_name,
+		Total_tax:          b.GetTotalTax(),
+		Federal_tax:        b.Federal.Tax,
+		Home_state_tax:     b.State.Tax,
+		Work_state_tax:     et.Work_state_tax,
+		Other_state_credit: et.Other_state_credit,
+		Home_locale_tax:    et.Home_locale_tax,
+		Work_locale_tax:    et.Work_locale_tax,
+		Payroll_tax:        et.Payroll_tax,
+	}
+	// refundable credits can exceed the taxes owed
+	cl.Net_refund = cl.Total_tax < 0
+
+	if explain {
+		cl.Breakdown = b
+	}
+
+	return cl
+}
+
+// get the taxes of a household living in the home county whose earners each work in a county of their own, with an
+// estimate for each tax locale of the home county and combination of tax locales the earners work in
+func (c *CountyServiceImpl) GetHousehold(home *model.CountyTaxList, earners []model.EarnerProfile, filer model.FilerProfile, explain bool) (*model.Household, *apperrors.AppError) {
+	filer.Tax_year = c.resolveTaxYear(filer.Tax_year)
+	household := &model.Household{
+		County_id:   home.County_id,
+		County_name: home.County_name,
+		State_id:    home.State_id,
+		State_name:  home.State_name,
+		Tax_year:    filer.Tax_year,
+		Projected:   c.stateService.isProjected(filer.Tax_year),
+		Tax_locale:  []model.HouseholdLocale{},
+	}
+
+	// the household files one return on the combined wages of its earners, and is resident where it lives
+	filer.Resident = true
+	filer.Income = 0
+	for _, e := range earners {
+		filer.Income = filer.Income + e.Income
+	}
+
+	for _, homeLocale := range home.Tax_locales {
+		for _, works := range getWorkLocaleCombinations(earners) {
+			homeLocale := homeLocale
+			b, err := c.stateService.processHouseholdTaxLiabilityById(home.State_id, filer, &homeLocale, works)
+			if err != nil {
+				return nil, err
+			}
+			hl := model.HouseholdLocale{
+				Locale_id:   homeLocale.Locale_id,
+				Locale_name: homeLocale.Local_name,
+				Total_tax:   b.GetTotalTax(),
+				Federal_tax: b.Federal.Tax,
+				State_tax:   b.State.Tax,
+				Earners:     []model.EarnerTax{},
+			}
+			for i, e := range earners {
+				et := buildEarnerTax(b.Earners[i])
+				et.Work_county_id = e.Work.County_id
+				et.Work_county_name = e.Work.County_name
+				et.Work_state_id = e.Work.State_id
+				et.Work_state_name = e.Work.State_name
+				et.Work_locale_id = works[i].Work_locale.Locale_id
+				et.Work_locale_name = works[i].Work_locale.Local_name
+				et.Reciprocity = e.Work.State_id != home.State_id && c.stateService.hasReciprocity(home.State_id, e.Work.State_id, filer)
+				hl.Earners = append(hl.Earners, et)
+			}
+			// refundable credits can exceed the taxes owed
+			hl.Net_refund = hl.Total_tax < 0
+
+			if explain {
+				hl.Breakdown = b
+			}
+			household.Tax_locale = append(household.Tax_locale, hl)
+		}
+	}
+
+	return household, nil
+}
+
+// helper function to list each combination of the tax locales the earners of a household work in
+func getWorkLocaleCombinations(earners []model.EarnerProfile) [][]model.EarnerWork {
+	combinations := [][]model.EarnerWork{{}}
+	for _, e := range earners {
+		next := [][]model.EarnerWork{}
+		for _, combination := range combinations {
+			for _, workLocale := range e.Work.Tax_locales {
+				w := model.EarnerWork{Income: e.Income, Work_state_id: e.Work.State_id, Work_locale: workLocale}
+				next = append(next, append(append([]model.EarnerWork{}, combination...), w))
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+// helper function to build the taxes charged on the wages of an earner from the computed breakdown
+func buildEarnerTax(eb model.EarnerTaxBreakdown) model.EarnerTax {
+	et := model.EarnerTax{Income: eb.Income, Total_tax: eb.GetTotalTax()}
+	if eb.Locale != nil {
+		et.Home_locale_tax = eb.Locale.Tax
+	}
+	if eb.Work_state != nil {
+		et.Work_state_tax = eb.Work_state.Tax
+	}
+	if eb.Other_state_credit != nil {
+		et.Other_state_credit = eb.Other_state_credit.Amount
+	}
+	if eb.Work_locale != nil {
+		et.Work_locale_tax = eb.Work_locale.Tax
+	}
+	if eb.Payroll != nil {
+		et.Payroll_tax = eb.Payroll.Tax
+	}
+
+	return et
 }
 
 func (c *CountyServiceImpl) GetCountyList(metricName string, n int, desc bool) (*model.CountyList, *apperrors.AppError) {
@@ -300,6 +741,129 @@ func (c *CountyServiceImpl) GetCountyList(metricName string, n int, desc bool) (
 	return countyList, nil
 }
 
+func (c *CountyServiceImpl) GetComputedCountyList(metricName string, n int, desc bool, filer model.FilerProfile) (*model.ComputedCountyList, *apperrors.AppError) {
+	// resolve the tax year the metrics are computed for
+	var err *apperrors.AppError
+	filer.Tax_year, err = c.stateService.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, err
+	}
+
+	// lowercase and trim the metric name
+	metricName = strings.TrimSpace(strings.ToLower(metricName))
+	if !model.IsComputedCountyMetric(metricName) {
+		return nil, apperrors.InvalidCountyMetric()
+	}
+
+	pairs, err := c.getComputedMetricPairs(metricName, filer)
+	if err != nil {
+		return nil, err
+	}
+
+	// rank a copy of the cached metrics, so the cached order is not changed by the request
+	countyList := model.GetComputedCountyList(metricName, filer.Tax_year, c.stateService.isProjected(filer.Tax_year))
+	countyList.Ranked_list = append(countyList.Ranked_list, pairs...)
+	countyList.RankCountyList(n, desc)
+
+	return countyList, nil
+}
+
+// helper method to get the metric of every county computed for the filer. The metrics are computed on the first request
+// for a metric and set of filer variables, then read from the cache
+func (c *CountyServiceImpl) getComputedMetricPairs(metric string, filer model.FilerProfile) ([]model.ComputedCountyMetricPair, *apperrors.AppError) {
+	key := computedListKey{metric: metric, filer: filer}
+	c.computedListsLock.Lock()
+	pairs, ok := c.computedLists[key]
+	c.computedListsLock.Unlock()
+	if ok {
+		logger.Info("Metric %s found in the computed list cache", metric)
+		return pairs, nil
+	}
+
+	allCounties, err := c.getAllCounties()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Computing metric %s for every county", metric)
+	pairs = []model.ComputedCountyMetricPair{}
+	for _, county := range allCounties {
+		pair, err := c.getComputedMetricPair(county, metric, filer)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+
+	// the filer variables vary by request, so the cache is cleared rather than grown past its size
+	c.computedListsLock.Lock()
+	if len(c.computedLists) >= COMPUTED_LIST_CACHE_SIZE {
+		c.computedLists = map[computedListKey][]model.ComputedCountyMetricPair{}
+	}
+	c.computedLists[key] = pairs
+	c.computedListsLock.Unlock()
+
+	return pairs, nil
+}
+
+// helper method to compute a metric of a county for the filer. Only the taxes the metric needs are estimated, without
+// building the tax locales of the county
+func (c *CountyServiceImpl) getComputedMetricPair(county cachedCounty, metric string, filer model.FilerProfile) (model.ComputedCountyMetricPair, *apperrors.AppError) {
+	_, _, filer = estimatePropertyTax(county.county, filer)
+	locales := []model.LocaleMetric{}
+	for _, taxLocale := range county.taxList.Tax_locales {
+		var b *model.TaxBreakdown
+		if metric == model.SalesTaxMetric {
+			salesTax := c.stateService.getSalesTax(filer, county.county.State_id, county.county.County_id, taxLocale.Locale_id)
+			b = &model.TaxBreakdown{Sales_tax: &salesTax}
+		} else {
+			var err *apperrors.AppError
+			b, err = c.stateService.processTaxLiabilityById(county.county.State_id, filer, &taxLocale)
+			if err != nil {
+				return model.ComputedCountyMetricPair{}, err
+			}
+		}
+		locales = append(locales, model.GetLocaleMetric(metric, taxLocale.Locale_id, taxLocale.Local_name, b, county.county.Average_rent))
+	}
+
+	return model.GetComputedCountyMetricPair(county.county, locales), nil
+}
+
+// helper method to get every county with its tax info. The counties are read once and never changed after, so they
+// can be ranged over while other requests update the caches
+func (c *CountyServiceImpl) getAllCounties() ([]cachedCounty, *apperrors.AppError) {
+	c.allCountiesLock.Lock()
+	defer c.allCountiesLock.Unlock()
+	if c.allCounties != nil {
+		return c.allCounties, nil
+	}
+
+	logger.Info("Querying data access layer for all counties")
+	allCountyData, err := c.daoImpl.GetAllCountyData()
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows of a county are next to each other in the response
+	logger.Info("Recieved response, reading the data of each county")
+	allCounties := []cachedCounty{}
+	start := 0
+	for i := range allCountyData {
+		if i+1 < len(allCountyData) && readAsInt(allCountyData[i+1][COUNTY_ID]) == readAsInt(allCountyData[start][COUNTY_ID]) {
+			continue
+		}
+		county, taxList, err := c.readCountyData(allCountyData[start : i+1])
+		if err != nil {
+			return nil, err
+		}
+		allCounties = append(allCounties, cachedCounty{county: county, taxList: taxList})
+		start = i + 1
+	}
+	c.allCounties = allCounties
+
+	return allCounties, nil
+}
+
 func (c *CountyServiceImpl) GetCountyTaxListById(id int) (*model.CountyTaxList, *apperrors.AppError) {
 	// check if id in map, if not get from db
 	countyTax, ok := c.countyTaxIdMp[id]
@@ -315,7 +879,10 @@ func (c *CountyServiceImpl) GetCountyTaxListById(id int) (*model.CountyTaxList,
 
 	// place the data in the maps and return the tax information list
 	logger.Info("Placing county %v data in the correct maps", id)
-	_, countyTax, _ = c.placeCountyDataInMaps(countyData, "H", false, 0, 0)
+	_, countyTax, err = c.placeCountyDataInMaps(countyData)
+	if err != nil {
+		return nil, err
+	}
 
 	return countyTax, nil
 }
@@ -336,7 +903,10 @@ func (c *CountyServiceImpl) GetCountyTaxListByName(name string) (*model.CountyTa
 
 	// place the data in the maps and return the tax information list
 	logger.Info("Placing county %s data in the correct maps", name)
-	_, countyTax, _ = c.placeCountyDataInMaps(countyData, "H", false, 0, 0)
+	_, countyTax, err = c.placeCountyDataInMaps(countyData)
+	if err != nil {
+		return nil, err
+	}
 
 	return countyTax, nil
 
@@ -349,7 +919,6 @@ func formatCountyInput(county string) string {
 	county = strings.TrimSpace(strings.ToLower(county))
 	if !strings.Contains(county, " county") {
 		county = county + " county"
-		print(county)
 	}
 
 	return county
@@ -0,0 +1,150 @@
+package services
+
+/* Federal tax parameters other than the brackets and deductions, by tax year */
+
+import (
+	"github.com/Matthew-Curry/re-region-api/src/model"
+)
+
+// payroll, credit, investment income, contribution, itemized deduction, and alternative minimum tax parameters of a tax year
+type federalParameters struct {
+	payrollTaxInfo            *model.PayrollTaxInfo
+	dependentCreditInfo       *model.DependentCreditInfo
+	earnedIncomeCreditInfo    *model.EarnedIncomeCreditInfo
+	investmentIncomeTaxInfo   *model.InvestmentIncomeTaxInfo
+	contributionLimits        *model.ContributionLimits
+	itemizedDeductionInfo     *model.ItemizedDeductionInfo
+	alternativeMinimumTaxInfo *model.AlternativeMinimumTaxInfo
+}
+
+// method to project the parameters to a later year by the cumulative cpi factor. The itemized deduction caps and
+// rates are set by statute and not indexed
+func (p *federalParameters) getProjection(factor float64) *federalParameters {
+	return &federalParameters{
+		payrollTaxInfo:            p.payrollTaxInfo.GetProjection(factor),
+		dependentCreditInfo:       p.dependentCreditInfo.GetProjection(factor),
+		earnedIncomeCreditInfo:    p.earnedIncomeCreditInfo.GetProjection(factor),
+		investmentIncomeTaxInfo:   p.investmentIncomeTaxInfo.GetProjection(factor),
+		contributionLimits:        p.contributionLimits.GetProjection(factor),
+		itemizedDeductionInfo:     p.itemizedDeductionInfo,
+		alternativeMinimumTaxInfo: p.alternativeMinimumTaxInfo.GetProjection(factor),
+	}
+}
+
+// number of filing statuses with their own thresholds
+const FILING_STATUS_COUNT = 5
+
+// indexes from the federal parameters response, the thresholds set by filing status are ordered single, married,
+// head of household, married filing separately, and surviving spouse
+const (
+	PARAMETERS_SOCIAL_SECURITY_RATE = iota
+	PARAMETERS_SOCIAL_SECURITY_WAGE_BASE
+	PARAMETERS_MEDICARE_RATE
+	PARAMETERS_ADDITIONAL_MEDICARE_RATE
+	PARAMETERS_CHILD_CREDIT
+	PARAMETERS_CHILD_REFUNDABLE_CREDIT
+	PARAMETERS_CHILD_REFUNDABLE_RATE
+	PARAMETERS_CHILD_REFUNDABLE_INCOME_THRESHOLD
+	PARAMETERS_OTHER_DEPENDENT_CREDIT
+	PARAMETERS_DEPENDENT_CREDIT_PHASE_OUT_AMOUNT
+	PARAMETERS_DEPENDENT_CREDIT_PHASE_OUT_STEP
+	PARAMETERS_SINGLE_DEPENDENT_CREDIT_THRESHOLD
+	PARAMETERS_MARRIED_DEPENDENT_CREDIT_THRESHOLD
+	PARAMETERS_EITC_INVESTMENT_INCOME_LIMIT
+	PARAMETERS_INVESTMENT_MID_RATE
+	PARAMETERS_INVESTMENT_TOP_RATE
+	PARAMETERS_NET_INVESTMENT_INCOME_RATE
+	PARAMETERS_RETIREMENT_LIMIT
+	PARAMETERS_IRA_LIMIT
+	PARAMETERS_HSA_SELF_LIMIT
+	PARAMETERS_HSA_FAMILY_LIMIT
+	PARAMETERS_SALT_CAP
+	PARAMETERS_SEPARATE_SALT_CAP
+	PARAMETERS_MEDICAL_FLOOR_RATE
+	PARAMETERS_CHARITABLE_LIMIT_RATE
+	PARAMETERS_AMT_LOW_RATE
+	PARAMETERS_AMT_HIGH_RATE
+	PARAMETERS_AMT_PHASE_OUT_RATE
+	PARAMETERS_ADDITIONAL_MEDICARE_THRESHOLDS
+	PARAMETERS_ZERO_RATE_THRESHOLDS      = PARAMETERS_ADDITIONAL_MEDICARE_THRESHOLDS + FILING_STATUS_COUNT
+	PARAMETERS_MID_RATE_THRESHOLDS       = PARAMETERS_ZERO_RATE_THRESHOLDS + FILING_STATUS_COUNT
+	PARAMETERS_NET_INVESTMENT_THRESHOLDS = PARAMETERS_MID_RATE_THRESHOLDS + FILING_STATUS_COUNT
+	PARAMETERS_AMT_EXEMPTIONS            = PARAMETERS_NET_INVESTMENT_THRESHOLDS + FILING_STATUS_COUNT
+	PARAMETERS_AMT_PHASE_OUT_THRESHOLDS  = PARAMETERS_AMT_EXEMPTIONS + FILING_STATUS_COUNT
+	PARAMETERS_AMT_RATE_THRESHOLDS       = PARAMETERS_AMT_PHASE_OUT_THRESHOLDS + FILING_STATUS_COUNT
+)
+
+// indexes from the earned income credit schedules response
+const (
+	EITC_CHILDREN = iota
+	EITC_CREDIT_RATE
+	EITC_MAX_CREDIT
+	EITC_PHASE_OUT_RATE
+	EITC_SINGLE_PHASE_OUT_THRESHOLD
+	EITC_MARRIED_PHASE_OUT_THRESHOLD
+)
+
+// constructor helper function, builds the parameters of a tax year from its row of parameters and its earned income
+// credit schedules
+func buildFederalParameters(parametersRow []interface{}, scheduleList [][]interface{}) *federalParameters {
+	// thresholds of a parameter for each filing status, starting at the index of the single threshold
+	statusInts := func(start int) []int {
+		thresholds := []int{}
+		for i := start; i < start+FILING_STATUS_COUNT; i++ {
+			thresholds = append(thresholds, readAsInt(parametersRow[i]))
+		}
+		return thresholds
+	}
+	investmentThresholds := func(status int) model.InvestmentIncomeThresholds {
+		return model.InvestmentIncomeThresholds{
+			Zero_rate_threshold:             readAsInt(parametersRow[PARAMETERS_ZERO_RATE_THRESHOLDS+status]),
+			Mid_rate_threshold:              readAsInt(parametersRow[PARAMETERS_MID_RATE_THRESHOLDS+status]),
+			Net_investment_income_threshold: readAsInt(parametersRow[PARAMETERS_NET_INVESTMENT_THRESHOLDS+status]),
+		}
+	}
+	amtThresholds := func(status int) model.AlternativeMinimumTaxThresholds {
+		return model.AlternativeMinimumTaxThresholds{
+			Exemption:           readAsInt(parametersRow[PARAMETERS_AMT_EXEMPTIONS+status]),
+			Phase_out_threshold: readAsInt(parametersRow[PARAMETERS_AMT_PHASE_OUT_THRESHOLDS+status]),
+			Rate_threshold:      readAsInt(parametersRow[PARAMETERS_AMT_RATE_THRESHOLDS+status]),
+		}
+	}
+
+	schedules := []model.EarnedIncomeCreditSchedule{}
+	for _, row := range scheduleList {
+		schedules = append(schedules, model.EarnedIncomeCreditSchedule{
+			Children:                    readAsInt(row[EITC_CHILDREN]),
+			Credit_rate:                 readAsFloat(row[EITC_CREDIT_RATE]),
+			Max_credit:                  readAsInt(row[EITC_MAX_CREDIT]),
+			Phase_out_rate:              readAsFloat(row[EITC_PHASE_OUT_RATE]),
+			Single_phase_out_threshold:  readAsInt(row[EITC_SINGLE_PHASE_OUT_THRESHOLD]),
+			Married_phase_out_threshold: readAsInt(row[EITC_MARRIED_PHASE_OUT_THRESHOLD]),
+		})
+	}
+
+	medicareThresholds := statusInts(PARAMETERS_ADDITIONAL_MEDICARE_THRESHOLDS)
+	return &federalParameters{
+		payrollTaxInfo: model.GetPayrollTaxInfo(readAsFloat(parametersRow[PARAMETERS_SOCIAL_SECURITY_RATE]),
+			readAsInt(parametersRow[PARAMETERS_SOCIAL_SECURITY_WAGE_BASE]), readAsFloat(parametersRow[PARAMETERS_MEDICARE_RATE]),
+			readAsFloat(parametersRow[PARAMETERS_ADDITIONAL_MEDICARE_RATE]), medicareThresholds[0], medicareThresholds[1],
+			medicareThresholds[2], medicareThresholds[3], medicareThresholds[4]),
+		dependentCreditInfo: model.GetDependentCreditInfo(readAsInt(parametersRow[PARAMETERS_CHILD_CREDIT]),
+			readAsInt(parametersRow[PARAMETERS_CHILD_REFUNDABLE_CREDIT]), readAsFloat(parametersRow[PARAMETERS_CHILD_REFUNDABLE_RATE]),
+			readAsInt(parametersRow[PARAMETERS_CHILD_REFUNDABLE_INCOME_THRESHOLD]), readAsInt(parametersRow[PARAMETERS_OTHER_DEPENDENT_CREDIT]),
+			readAsInt(parametersRow[PARAMETERS_DEPENDENT_CREDIT_PHASE_OUT_AMOUNT]), readAsInt(parametersRow[PARAMETERS_DEPENDENT_CREDIT_PHASE_OUT_STEP]),
+			readAsInt(parametersRow[PARAMETERS_SINGLE_DEPENDENT_CREDIT_THRESHOLD]), readAsInt(parametersRow[PARAMETERS_MARRIED_DEPENDENT_CREDIT_THRESHOLD])),
+		earnedIncomeCreditInfo: model.GetEarnedIncomeCreditInfo(readAsInt(parametersRow[PARAMETERS_EITC_INVESTMENT_INCOME_LIMIT]), schedules),
+		investmentIncomeTaxInfo: model.GetInvestmentIncomeTaxInfo(readAsFloat(parametersRow[PARAMETERS_INVESTMENT_MID_RATE]),
+			readAsFloat(parametersRow[PARAMETERS_INVESTMENT_TOP_RATE]), readAsFloat(parametersRow[PARAMETERS_NET_INVESTMENT_INCOME_RATE]),
+			investmentThresholds(0), investmentThresholds(1), investmentThresholds(2), investmentThresholds(3), investmentThresholds(4)),
+		contributionLimits: model.GetContributionLimits(readAsInt(parametersRow[PARAMETERS_RETIREMENT_LIMIT]),
+			readAsInt(parametersRow[PARAMETERS_IRA_LIMIT]), readAsInt(parametersRow[PARAMETERS_HSA_SELF_LIMIT]),
+			readAsInt(parametersRow[PARAMETERS_HSA_FAMILY_LIMIT])),
+		itemizedDeductionInfo: model.GetItemizedDeductionInfo(readAsInt(parametersRow[PARAMETERS_SALT_CAP]),
+			readAsInt(parametersRow[PARAMETERS_SEPARATE_SALT_CAP]), readAsFloat(parametersRow[PARAMETERS_MEDICAL_FLOOR_RATE]),
+			readAsFloat(parametersRow[PARAMETERS_CHARITABLE_LIMIT_RATE])),
+		alternativeMinimumTaxInfo: model.GetAlternativeMinimumTaxInfo(readAsFloat(parametersRow[PARAMETERS_AMT_LOW_RATE]),
+			readAsFloat(parametersRow[PARAMETERS_AMT_HIGH_RATE]), readAsFloat(parametersRow[PARAMETERS_AMT_PHASE_OUT_RATE]),
+			amtThresholds(0), amtThresholds(1), amtThresholds(2), amtThresholds(3), amtThresholds(4)),
+	}
+}
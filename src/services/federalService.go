@@ -9,7 +9,17 @@ import (
 
 type FederalServiceInterface interface {
 	// public method for controller get overall federal tax information
-	GetFederalTaxInfo() (*model.FederalTaxInfo, *apperrors.AppError)
-	// return estimated federal liability
-	getFederalLiability(filingStatus model.FilingStatus, dependents int, income int) int
+	GetFederalTaxInfo(year int) (*model.FederalTaxInfo, *apperrors.AppError)
+	// public method to resolve the requested tax year, 0 resolves to the default year
+	GetTaxYear(year int) (int, *apperrors.AppError)
+	// return the annual cpi growth used to project tax years that are not yet published
+	getCpiFactor() float64
+	// return estimated federal liability with the inputs used to compute it, the state and local income tax
+	// is deductible when itemizing. The ira deduction adjusts income but not earned income
+	getFederalLiability(filer model.FilerProfile, adjustments, iraDeduction, stateLocalTax int) model.IncomeTaxBreakdown
+	// return estimated social security and medicare liability, or self-employment tax. The threshold share splits the
+	// additional medicare threshold between the earners of a joint return
+	getPayrollLiability(filer model.FilerProfile, contributions model.PreTaxContributions, thresholdShare float64) model.PayrollTaxBreakdown
+	// return the pre-tax contributions of the filer within the annual limits
+	getPreTaxContributions(filer model.FilerProfile) model.PreTaxContributions
 }
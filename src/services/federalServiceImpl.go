@@ -14,38 +14,90 @@ const (
 	FEDERAL_SINGLE_BRACKET
 	FEDERAL_MARRIED_BRACKET
 	FEDERAL_HEAD_BRACKET
+	FEDERAL_SEPARATE_BRACKET
+	FEDERAL_SURVIVING_BRACKET
 	FEDERAL_STANDARD_DEDUCTION
 	FEDERAL_MARRIED_DEDUCTION
 	FEDERAL_HEAD_DEDUCTION
+	FEDERAL_SEPARATE_DEDUCTION
+	FEDERAL_SURVIVING_DEDUCTION
 )
 
 type FederalServiceImpl struct {
-	federalTaxInfo *model.FederalTaxInfo
+	// federal brackets and deductions of each loaded tax year
+	federalTaxInfoMp map[int]*model.FederalTaxInfo
+	defaultYear      int
+	// annual cpi growth used to project the years after the latest published year
+	cpiFactor float64
+	// payroll, credit, and other federal parameters of each loaded tax year
+	federalParametersMp map[int]*federalParameters
 }
 
-// constructor to return this implementation of the federal service
-func GetFederalServiceImpl(daoImpl dao.DaoInterface) (FederalServiceInterface, *apperrors.AppError) {
-	// use dao to retrieve the federaltaxlist
-	logger.Info("Getting federal tax data from data access layer")
-	federalTaxList, err := daoImpl.GetFederalTaxData()
+// constructor to return this implementation of the federal service, the cpi factor is the annual cpi growth
+// used to project tax years that are not yet published
+func GetFederalServiceImpl(daoImpl dao.DaoInterface, cpiFactor float64) (FederalServiceInterface, *apperrors.AppError) {
+	// use dao to retrieve the federaltaxlist of each tax year
+	logger.Info("Getting tax years from data access layer")
+	yearList, err := daoImpl.GetTaxYears()
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Info("Caching the response")
-	federalTaxInfo := buildCachedResponse(federalTaxList)
+	federalTaxInfoMp := map[int]*model.FederalTaxInfo{}
+	federalParametersMp := map[int]*federalParameters{}
+	years := []int{}
+	for _, row := range yearList {
+		year := readAsInt(row[0])
+		logger.Info("Getting federal parameters for %v from data access layer", year)
+		parametersList, err := daoImpl.GetFederalParameters(year)
+		if err != nil {
+			return nil, err
+		}
+		scheduleList, err := daoImpl.GetEarnedIncomeCreditSchedules(year)
+		if err != nil {
+			return nil, err
+		}
+		// a year is only supported with its published parameters, requests for a year that is not loaded are rejected
+		if len(parametersList) == 0 || len(scheduleList) == 0 {
+			logger.Warn("No federal parameters for %v, the year is not loaded", year)
+			continue
+		}
+
+		logger.Info("Getting federal tax data for %v from data access layer", year)
+		federalTaxList, err := daoImpl.GetFederalTaxData(year)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info("Caching the response")
+		federalTaxInfoMp[year] = buildCachedResponse(federalTaxList, year)
+		federalParametersMp[year] = buildFederalParameters(parametersList[0], scheduleList)
+		years = append(years, year)
+	}
+
+	// project the years after the latest published year from its tables
+	if latest := getLatestTaxYear(years); latest != 0 {
+		for n := 1; n <= PROJECTED_YEARS; n++ {
+			logger.Info("Projecting federal tax data for %v", latest+n)
+			federalTaxInfoMp[latest+n] = federalTaxInfoMp[latest].GetProjection(latest+n, getCumulativeCpiFactor(cpiFactor, n))
+			federalParametersMp[latest+n] = federalParametersMp[latest].getProjection(getCumulativeCpiFactor(cpiFactor, n))
+		}
+	}
 	logger.Info("Federal tax cache created")
 
-	return &FederalServiceImpl{federalTaxInfo: federalTaxInfo}, nil
+	return &FederalServiceImpl{federalTaxInfoMp: federalTaxInfoMp, defaultYear: getDefaultTaxYear(years), cpiFactor: cpiFactor,
+		federalParametersMp: federalParametersMp}, nil
 
 }
 
 // public method to get overall federal tax information
-func buildCachedResponse(federalTaxList [][]interface{}) *model.FederalTaxInfo {
+func buildCachedResponse(federalTaxList [][]interface{}, year int) *model.FederalTaxInfo {
 	// get constant attributes from first record
 	sd := readAsInt(federalTaxList[0][FEDERAL_STANDARD_DEDUCTION])
 	md := readAsInt(federalTaxList[0][FEDERAL_MARRIED_DEDUCTION])
 	hd := readAsInt(federalTaxList[0][FEDERAL_HEAD_DEDUCTION])
+	spd := readAsInt(federalTaxList[0][FEDERAL_SEPARATE_DEDUCTION])
+	svd := readAsInt(federalTaxList[0][FEDERAL_SURVIVING_DEDUCTION])
 
 	// pass over brackets to form the bracket list
 	bracketList := []model.FederalBracket{}
@@ -56,37 +108,159 @@ func buildCachedResponse(federalTaxList [][]interface{}) *model.FederalTaxInfo {
 		sb := readAsInt(row[FEDERAL_SINGLE_BRACKET])
 		mb := readAsInt(row[FEDERAL_MARRIED_BRACKET])
 		hb := readAsInt(row[FEDERAL_HEAD_BRACKET])
-		b := model.FederalBracket{Rate: r, Single_bracket: sb, Married_bracket: mb, Head_bracket: hb}
+		spb := readAsInt(row[FEDERAL_SEPARATE_BRACKET])
+		svb := readAsInt(row[FEDERAL_SURVIVING_BRACKET])
+		b := model.FederalBracket{Rate: r, Single_bracket: sb, Married_bracket: mb, Head_bracket: hb, Separate_bracket: spb, Surviving_bracket: svb}
 		bracketList = append(bracketList, b)
 	}
 
 	// form complete response
-	return model.GetFederalTaxInfo(sd, md, hd, bracketList)
+	return model.GetFederalTaxInfo(year, sd, md, hd, spd, svd, bracketList)
 }
 
-// public method to return the federal tax information
-func (f *FederalServiceImpl) GetFederalTaxInfo() (*model.FederalTaxInfo, *apperrors.AppError) {
-	if f.federalTaxInfo == nil {
+// public method to return the federal tax information of a tax year, 0 for the default year
+func (f *FederalServiceImpl) GetFederalTaxInfo(year int) (*model.FederalTaxInfo, *apperrors.AppError) {
+	if len(f.federalTaxInfoMp) == 0 {
 		return nil, apperrors.EmptyFederalCache()
 	}
-	return f.federalTaxInfo, nil
+
+	year, err := f.GetTaxYear(year)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.federalTaxInfoMp[year], nil
+}
+
+// public method to resolve a requested tax year, 0 for the default year
+func (f *FederalServiceImpl) GetTaxYear(year int) (int, *apperrors.AppError) {
+	if year == 0 {
+		return f.defaultYear, nil
+	}
+
+	if _, ok := f.federalTaxInfoMp[year]; !ok {
+		return 0, apperrors.TaxYearNotFound(year)
+	}
+
+	return year, nil
+}
+
+// helper method to get the federal parameters of a tax year, falling back to the default year
+func (f *FederalServiceImpl) getFederalParameters(year int) *federalParameters {
+	p, ok := f.federalParametersMp[year]
+	if !ok {
+		p = f.federalParametersMp[f.defaultYear]
+	}
+
+	return p
+}
+
+// get the annual cpi growth used to project tax years
+func (f *FederalServiceImpl) getCpiFactor() float64 {
+	return f.cpiFactor
 }
 
-// method to get overall federal tax liability
-func (f *FederalServiceImpl) getFederalLiability(filingStatus model.FilingStatus, dependents int, income int) int {
-	// use filing status to determine state deduction and exemption
-	switch filingStatus {
+// method to get overall federal tax liability along with the inputs used to compute it. Adjustments and the ira
+// deduction are subtracted from income before the deduction, credits are applied after the bracket tax
+func (f *FederalServiceImpl) getFederalLiability(filer model.FilerProfile, adjustments, iraDeduction, stateLocalTax int) model.IncomeTaxBreakdown {
+	// earned income is wages or net earnings less the deferrals taken from wages and the deductible self-employment
+	// tax. Ira contributions are not taken from wages, so only reduce adjusted gross income
+	earnedIncome := filer.Income - adjustments
+	adjustments = adjustments + iraDeduction
+
+	// use filing status to determine the deduction and the schedule ordinary income is taxed at
+	var deduction int
+	var getOrdinaryLiability func(int) (int, []model.BracketLiability)
+	federalTaxInfo, ok := f.federalTaxInfoMp[filer.Tax_year]
+	if !ok {
+		federalTaxInfo = f.federalTaxInfoMp[f.defaultYear]
+	}
+	switch filer.Filing_status {
 	case model.Head:
-		income = getTaxableIncome(income, f.federalTaxInfo.Single_deduction, 0, 0)
-		return f.federalTaxInfo.GetHeadTaxLiability(income)
+		deduction, getOrdinaryLiability = federalTaxInfo.Head_deduction, federalTaxInfo.GetHeadTaxLiability
 	case model.Single:
-		income = getTaxableIncome(income, f.federalTaxInfo.Head_deduction, 0, 0)
-		return f.federalTaxInfo.GetSingleTaxLiability(income)
+		deduction, getOrdinaryLiability = federalTaxInfo.Single_deduction, federalTaxInfo.GetSingleTaxLiability
 	case model.Married:
-		income = getTaxableIncome(income, f.federalTaxInfo.Married_deduction, 0, 0)
-		return f.federalTaxInfo.GetMarriedTaxLiability(income)
+		deduction, getOrdinaryLiability = federalTaxInfo.Married_deduction, federalTaxInfo.GetMarriedTaxLiability
+	case model.MarriedSeparately:
+		deduction, getOrdinaryLiability = federalTaxInfo.Separate_deduction, federalTaxInfo.GetSeparateTaxLiability
+	case model.SurvivingSpouse:
+		deduction, getOrdinaryLiability = federalTaxInfo.Surviving_deduction, federalTaxInfo.GetSurvivingTaxLiability
 	}
 
-	return 0
+	p := f.getFederalParameters(filer.Tax_year)
+	income := filer.GetTotalIncome()
+	var b model.IncomeTaxBreakdown
+	if getOrdinaryLiability != nil {
+		// itemize when the itemized deductions are larger than the standard deduction
+		itemized := p.itemizedDeductionInfo.GetItemizedDeductions(filer, income-adjustments, stateLocalTax)
+		deductionType := model.StandardDeduction
+		if itemized.Total > deduction {
+			deduction = itemized.Total
+			deductionType = model.ItemizedDeduction
+		}
+
+		b = getIncomeTaxBreakdown(income, adjustments, deduction, 0, 0, 0)
+		b.Deduction_type = deductionType
+		b.Itemized_deductions = &itemized
+
+		// preferential income is taxed last, so the deduction is taken from ordinary income first
+		b.Preferential_income = filer.GetPreferentialIncome()
+		if b.Preferential_income > b.Taxable_income {
+			b.Preferential_income = b.Taxable_income
+		}
+		ordinaryIncome := b.Taxable_income - b.Preferential_income
+		b.Tax, b.Brackets = getOrdinaryLiability(ordinaryIncome)
+
+		if b.Preferential_income > 0 {
+			var preferentialTax int
+			preferentialTax, b.Preferential_brackets = p.investmentIncomeTaxInfo.GetPreferentialLiability(filer.Filing_status,
+				ordinaryIncome, b.Preferential_income)
+			b.Tax = b.Tax + preferentialTax
+		}
+
+		// the alternative minimum tax allows no standard deduction or state and local tax deduction
+		amtIncome := income - adjustments
+		if deductionType == model.ItemizedDeduction {
+			amtIncome = amtIncome - itemized.Total + itemized.Salt
+		}
+		amt := p.alternativeMinimumTaxInfo.GetAlternativeMinimumTax(filer.Filing_status, amtIncome, filer.GetPreferentialIncome(), b.Tax,
+			p.investmentIncomeTaxInfo)
+		b.Alternative_minimum_tax = &amt
+		b.Tax = b.Tax + amt.Tax
+
+		b.Net_investment_income_tax = p.investmentIncomeTaxInfo.GetNetInvestmentIncomeTax(filer.Filing_status, income-adjustments,
+			filer.GetInvestmentIncome())
+		b.Tax = b.Tax + b.Net_investment_income_tax
+	}
+	b.Schedule = filer.Filing_status
+
+	// adjusted gross income sets the phase-outs
+	agi := income - adjustments
+	b.Tax_before_credits = b.Tax
+	ctc, odc := p.dependentCreditInfo.GetDependentCredits(filer.Filing_status, agi, earnedIncome, b.Tax, filer.Qualifying_children,
+		filer.Dependents-filer.Qualifying_children)
+	// the earned income credit is applied against the tax left after the nonrefundable dependent credits
+	eitc := p.earnedIncomeCreditInfo.GetEarnedIncomeCredit(filer.Filing_status, agi, earnedIncome, filer.GetInvestmentIncome(),
+		b.Tax-ctc.Nonrefundable-odc.Nonrefundable, filer.Qualifying_children)
+	b.Credits = []model.TaxCredit{ctc, odc, eitc}
+	b.Tax = b.Tax - ctc.Amount - odc.Amount - eitc.Amount
+
+	return b
+
+}
+
+// method to get the payroll tax liability on the wages of the filer, or the self-employment tax
+// on net earnings if the filer is self-employed. Contributions made through payroll reduce the wages of employees
+func (f *FederalServiceImpl) getPayrollLiability(filer model.FilerProfile, contributions model.PreTaxContributions, thresholdShare float64) model.PayrollTaxBreakdown {
+	if filer.Employment_type == model.SelfEmployed {
+		return f.getFederalParameters(filer.Tax_year).payrollTaxInfo.GetSelfEmploymentLiability(filer.Filing_status, filer.Income, thresholdShare)
+	}
+
+	return f.getFederalParameters(filer.Tax_year).payrollTaxInfo.GetPayrollLiability(filer.Filing_status, filer.Income-contributions.GetPayrollReduction(), thresholdShare)
+}
 
+// method to get the pre-tax contributions of the filer within the annual limits
+func (f *FederalServiceImpl) getPreTaxContributions(filer model.FilerProfile) model.PreTaxContributions {
+	return f.getFederalParameters(filer.Tax_year).contributionLimits.GetPreTaxContributions(filer)
 }
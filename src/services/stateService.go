@@ -8,17 +8,35 @@ import (
 )
 
 type StateServiceInterface interface {
-	// public methods to request a state. Also takes filing status, dependents, and income to estimate taxes
-	GetStateById(id int, fs model.FilingStatus, dependents int, income int) (*model.State, *apperrors.AppError)
-	GetStateByName(name string, fs model.FilingStatus, dependents int, income int) (*model.State, *apperrors.AppError)
+	// public methods to request a state. Also takes the tax filer variables to estimate taxes, and
+	// whether to explain the computation of the estimate
+	GetStateById(id int, filer model.FilerProfile, explain bool) (*model.State, *apperrors.AppError)
+	GetStateByName(name string, filer model.FilerProfile, explain bool) (*model.State, *apperrors.AppError)
+	// public methods to request the gross income needed in a state for the filer to take home a target net income
+	GetGrossUpById(id, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError)
+	GetGrossUpByName(name string, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError)
+	// public methods to request the lowest income in a state whose disposable income for the filer reaches that of an
+	// origin region
+	GetSalaryEquivalenceById(id int, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError)
+	GetSalaryEquivalenceByName(name string, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError)
 	// public methods to request state list by metric name, list size, and whether the list is ascending or descending
 	GetStateList(metricName string, n int, desc bool) (*model.StateList, *apperrors.AppError)
-	// public methods to request the tax info for a state
-	GetStateTaxInfoById(id int) (*model.StateTaxInfo, *apperrors.AppError)
-	GetStateTaxInfoByName(name string) (*model.StateTaxInfo, *apperrors.AppError)
+	// public methods to request the tax info for a state in a tax year, 0 for the default year
+	GetStateTaxInfoById(id, year int) (*model.StateTaxInfo, *apperrors.AppError)
+	GetStateTaxInfoByName(name string, year int) (*model.StateTaxInfo, *apperrors.AppError)
 	// internal methods to the package
+	// resolve the requested tax year, 0 resolves to the default year
+	getTaxYear(year int) (int, *apperrors.AppError)
+	// whether the tax tables of a year are projected rather than published
+	isProjected(year int) bool
 	// lookup of state id to name
 	getStateNameById(id int) (string, *apperrors.AppError)
-	// process state tax liability given the id
-	processTaxLiabilityById(id int, filingStatus model.FilingStatus, dependents int, income int) (int, int, int)
+	// sales tax of the filer at the combined rate of a state, county, and tax locale
+	getSalesTax(filer model.FilerProfile, stateId, countyId, localeId int) model.SalesTaxBreakdown
+	// lookup of whether a reciprocity agreement exempts the filer's wages from the work state tax
+	hasReciprocity(residentId, workId int, filer model.FilerProfile) bool
+	// process state, federal, and optionally local tax liability given the id
+	processTaxLiabilityById(id int, filer model.FilerProfile, taxLocale *model.TaxLocaleInfo) (*model.TaxBreakdown, *apperrors.AppError)
+	// process the liability of a household, resident in the home state and locale and nonresident where each earner works
+	processHouseholdTaxLiabilityById(homeId int, filer model.FilerProfile, homeLocale *model.TaxLocaleInfo, earners []model.EarnerWork) (*model.TaxBreakdown, *apperrors.AppError)
 }
@@ -30,15 +30,52 @@ const (
 	TAX_STATE_NAME
 	SINGLE_DEDUCTION
 	MARRIED_DEDUCTION
+	HEAD_DEDUCTION
 	SINGLE_EXEMPTION
 	MARRIED_EXEMPTION
+	HEAD_EXEMPTION
 	DEPENDENT_EXEMPTION
 	SINGLE_RATE
 	SINGLE_BRACKET
 	MARRIED_RATE
 	MARRIED_BRACKET
+	HEAD_RATE
+	HEAD_BRACKET
+	HEAD_FALLBACK
+	EITC_PERCENT
+	CAPITAL_GAINS_EXCLUSION
+	RETIREMENT_DEDUCTIBLE
+	HSA_DEDUCTIBLE
+	INDEXED
 )
 
+// indexes for the state reciprocity agreements
+const (
+	RECIPROCITY_RESIDENT_STATE_ID = iota
+	RECIPROCITY_WORK_STATE_ID
+)
+
+// indexes for the sales tax rates, the county and locale ids are 0 for the rates of broader areas
+const (
+	SALES_TAX_STATE_ID = iota
+	SALES_TAX_COUNTY_ID
+	SALES_TAX_LOCALE_ID
+	SALES_TAX_RATE
+)
+
+// state list metric ranking states by the sales tax on the spending of a filer earning the state median income
+const SALES_TAX_METRIC string = "sales_tax"
+
+// marginal shares of income spent on goods and services subject to sales tax, used when a filer does not give
+// their annual spending
+var consumptionShares = []model.ConsumptionShare{
+	{Income_bound: 0, Share: 0.35},
+	{Income_bound: 25000, Share: 0.30},
+	{Income_bound: 50000, Share: 0.22},
+	{Income_bound: 100000, Share: 0.15},
+	{Income_bound: 200000, Share: 0.08},
+}
+
 // metrics from the state data response mapped to the index they will be read in to
 var metrics = map[string]int{"pop": STATE_POP,
 	"male_pop":      STATE_MALE_POP,
@@ -56,9 +93,19 @@ type StateServiceImpl struct {
 	// map of metrics to ranked lists of state according to the metric
 	metricListMp map[string]*model.StateList
 
-	// maps for tax info endpoint
-	stateTaxNameMp map[string]*model.StateTaxInfo
-	stateTaxIdMp   map[int]*model.StateTaxInfo
+	// maps for tax info endpoint, keyed by tax year
+	stateTaxNameMp map[int]map[string]*model.StateTaxInfo
+	stateTaxIdMp   map[int]map[int]*model.StateTaxInfo
+	defaultYear    int
+	latestYear     int
+
+	// map of resident state ids to the work state ids they have reciprocity agreements with
+	reciprocityMp map[int]map[int]bool
+
+	// maps of state, county, and tax locale ids to their sales tax rates
+	stateSalesTaxMp  map[int]float64
+	countySalesTaxMp map[int]float64
+	localeSalesTaxMp map[int]float64
 
 	// use provided impl of federal service to access federal tax information
 	federalService FederalServiceInterface
@@ -75,8 +122,40 @@ func GetStateServiceImpl(daoImpl dao.DaoInterface, federalService FederalService
 		return nil, err
 	}
 
-	logger.Info("Getting state tax data from the data access layer")
-	stateTaxData, err := daoImpl.GetStateTax()
+	logger.Info("Getting tax years from the data access layer")
+	yearList, err := daoImpl.GetTaxYears()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stateTaxData := make(map[int][][]interface{})
+	years := []int{}
+	for _, row := range yearList {
+		year := readAsInt(row[0])
+		logger.Info("Getting state tax data for %v from the data access layer", year)
+		yearData, err := daoImpl.GetStateTax(year)
+
+		if err != nil {
+			return nil, err
+		}
+		if len(yearData) == 0 {
+			logger.Warn("No state tax data for %v, skipping the year", year)
+			continue
+		}
+		stateTaxData[year] = yearData
+		years = append(years, year)
+	}
+
+	logger.Info("Getting state reciprocity agreements from the data access layer")
+	reciprocityData, err := daoImpl.GetStateReciprocity()
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Getting sales tax rates from the data access layer")
+	salesTaxData, err := daoImpl.GetSalesTaxRates()
 
 	if err != nil {
 		return nil, err
@@ -90,17 +169,93 @@ func GetStateServiceImpl(daoImpl dao.DaoInterface, federalService FederalService
 	metricListMp := buildStateListCaches(stateCensusData)
 	logger.Info("State list cache created")
 
-	stateTaxIdMp, stateTaxNameMp := buildStateTaxCaches(stateTaxData)
+	stateTaxIdMp := make(map[int]map[int]*model.StateTaxInfo)
+	stateTaxNameMp := make(map[int]map[string]*model.StateTaxInfo)
+	for year, data := range stateTaxData {
+		stateTaxIdMp[year], stateTaxNameMp[year] = buildStateTaxCaches(data, year)
+	}
+
+	// project the years after the latest published year from its tables
+	latestYear := getLatestTaxYear(years)
+	if latestYear != 0 {
+		for n := 1; n <= PROJECTED_YEARS; n++ {
+			factor := getCumulativeCpiFactor(federalService.getCpiFactor(), n)
+			stateTaxIdMp[latestYear+n], stateTaxNameMp[latestYear+n] = projectStateTaxCaches(stateTaxIdMp[latestYear], latestYear+n, factor)
+		}
+	}
 	logger.Info("State tax cache created")
+
+	reciprocityMp := buildReciprocityCache(reciprocityData)
+	logger.Info("State reciprocity cache created")
+
+	stateSalesTaxMp, countySalesTaxMp, localeSalesTaxMp := buildSalesTaxCaches(salesTaxData)
+	metricListMp[SALES_TAX_METRIC] = buildSalesTaxStateList(stateCensusData, stateSalesTaxMp)
+	logger.Info("Sales tax cache created")
 	logger.Info("All state caches are now created")
 
 	// return the constructed service
 	return &StateServiceImpl{stateNameMp: stateNameMp,
-		stateIdMp:      stateIdMp,
-		metricListMp:   metricListMp,
-		stateTaxNameMp: stateTaxNameMp,
-		stateTaxIdMp:   stateTaxIdMp,
-		federalService: federalService}, nil
+		stateIdMp:        stateIdMp,
+		metricListMp:     metricListMp,
+		stateTaxNameMp:   stateTaxNameMp,
+		stateTaxIdMp:     stateTaxIdMp,
+		defaultYear:      getDefaultTaxYear(years),
+		latestYear:       latestYear,
+		reciprocityMp:    reciprocityMp,
+		stateSalesTaxMp:  stateSalesTaxMp,
+		countySalesTaxMp: countySalesTaxMp,
+		localeSalesTaxMp: localeSalesTaxMp,
+		federalService:   federalService}, nil
+}
+
+// constructor helper method, builds the cache of reciprocity agreements
+func buildReciprocityCache(reciprocityData [][]interface{}) map[int]map[int]bool {
+	mp := make(map[int]map[int]bool)
+	for _, row := range reciprocityData {
+		residentId := readAsInt(row[RECIPROCITY_RESIDENT_STATE_ID])
+		if _, ok := mp[residentId]; !ok {
+			mp[residentId] = make(map[int]bool)
+		}
+		mp[residentId][readAsInt(row[RECIPROCITY_WORK_STATE_ID])] = true
+	}
+
+	return mp
+}
+
+// constructor helper method, builds the caches of sales tax rates. Each row holds the rate of the most specific area
+// it has an id for
+func buildSalesTaxCaches(salesTaxData [][]interface{}) (map[int]float64, map[int]float64, map[int]float64) {
+	stateMp := make(map[int]float64)
+	countyMp := make(map[int]float64)
+	localeMp := make(map[int]float64)
+	for _, row := range salesTaxData {
+		rate := readAsFloat(row[SALES_TAX_RATE])
+		if li := readAsInt(row[SALES_TAX_LOCALE_ID]); li != 0 {
+			localeMp[li] = rate
+		} else if ci := readAsInt(row[SALES_TAX_COUNTY_ID]); ci != 0 {
+			countyMp[ci] = rate
+		} else {
+			stateMp[readAsInt(row[SALES_TAX_STATE_ID])] = rate
+		}
+	}
+
+	return stateMp, countyMp, localeMp
+}
+
+// constructor helper method, builds the listing of states by the sales tax on the spending of a filer earning the
+// state median income
+func buildSalesTaxStateList(stateCensusData [][]interface{}, stateSalesTaxMp map[int]float64) *model.StateList {
+	list := model.GetMetricStateList(SALES_TAX_METRIC)
+	for _, state := range stateCensusData {
+		si := readAsInt(state[CENSUS_STATE_ID])
+		filer := model.FilerProfile{Income: readAsInt(state[STATE_MEDIAN_INCOME])}
+		salesTax := model.GetSalesTaxBreakdown(filer, model.SalesTaxRates{State_rate: stateSalesTaxMp[si]}, consumptionShares)
+		list.AppendToRankedLists(model.StateMetricPair{State_id: si,
+			State_name:   readAsString(state[CENSUS_STATE_NAME]),
+			Metric_value: salesTax.Tax})
+	}
+
+	return list
 }
 
 // constructor helper method, builds state caches
@@ -142,7 +297,7 @@ func buildStateListCaches(stateCensusData [][]interface{}) map[string]*model.Sta
 }
 
 // constructor helper method, builds the static tax info caches
-func buildStateTaxCaches(stateTaxData [][]interface{}) (map[int]*model.StateTaxInfo, map[string]*model.StateTaxInfo) {
+func buildStateTaxCaches(stateTaxData [][]interface{}, year int) (map[int]*model.StateTaxInfo, map[string]*model.StateTaxInfo) {
 	idMp := make(map[int]*model.StateTaxInfo)
 	nameMp := make(map[string]*model.StateTaxInfo)
 	for _, row := range stateTaxData {
@@ -150,8 +305,11 @@ func buildStateTaxCaches(stateTaxData [][]interface{}) (map[int]*model.StateTaxI
 		si := readAsInt(row[TAX_STATE_ID])
 		sn := readAsString(row[TAX_STATE_NAME])
 		if _, ok := idMp[si]; !ok {
-			stateTaxInfo := model.GetStateTaxInfo(si, sn, readAsInt(row[SINGLE_DEDUCTION]), readAsInt(row[MARRIED_DEDUCTION]),
-				readAsInt(row[SINGLE_EXEMPTION]), readAsInt(row[MARRIED_EXEMPTION]), readAsInt(row[DEPENDENT_EXEMPTION]))
+			stateTaxInfo := model.GetStateTaxInfo(year, si, sn, readAsInt(row[SINGLE_DEDUCTION]), readAsInt(row[MARRIED_DEDUCTION]),
+				readAsInt(row[HEAD_DEDUCTION]), readAsInt(row[SINGLE_EXEMPTION]), readAsInt(row[MARRIED_EXEMPTION]),
+				readAsInt(row[HEAD_EXEMPTION]), readAsInt(row[DEPENDENT_EXEMPTION]), readAsBool(row[HEAD_FALLBACK]),
+				readAsFloat(row[EITC_PERCENT]), readAsFloat(row[CAPITAL_GAINS_EXCLUSION]),
+				readAsBool(row[RETIREMENT_DEDUCTIBLE]), readAsBool(row[HSA_DEDUCTIBLE]), readAsBool(row[INDEXED]))
 
 			// lowercase the name + trim space to provide a standard naming API
 			sn = strings.TrimSpace(strings.ToLower(sn))
@@ -166,36 +324,64 @@ func buildStateTaxCaches(stateTaxData [][]interface{}) (map[int]*model.StateTaxI
 			Single_bracket:  readAsInt(row[SINGLE_BRACKET]),
 			Married_rate:    readAsFloat(row[MARRIED_RATE]),
 			Married_bracket: readAsInt(row[MARRIED_BRACKET]),
+			Head_rate:       readAsFloat(row[HEAD_RATE]),
+			Head_bracket:    readAsInt(row[HEAD_BRACKET]),
 		}
 		// add bracket to list in order of the single rate so they ascend properly. Call for just one map
 		// because they each point to the same tax info
 		idMp[si].AppendToOrderedList(sb)
+		// the state has a head of household schedule if any of its brackets has one
+		idMp[si].Head_fallback = idMp[si].Head_fallback && readAsBool(row[HEAD_FALLBACK])
+
+	}
+
+	return idMp, nameMp
+}
 
+// constructor helper method, builds the tax info caches of a projected year from the caches of the latest published year
+func projectStateTaxCaches(latestIdMp map[int]*model.StateTaxInfo, year int, factor float64) (map[int]*model.StateTaxInfo, map[string]*model.StateTaxInfo) {
+	idMp := make(map[int]*model.StateTaxInfo)
+	nameMp := make(map[string]*model.StateTaxInfo)
+	for si, ti := range latestIdMp {
+		stateTaxInfo := ti.GetProjection(year, factor)
+		idMp[si] = stateTaxInfo
+		nameMp[strings.TrimSpace(strings.ToLower(ti.State_name))] = stateTaxInfo
 	}
 
 	return idMp, nameMp
 }
 
 // get census and tax information by ID
-func (s *StateServiceImpl) GetStateById(id int, fs model.FilingStatus, dependents int, income int) (*model.State, *apperrors.AppError) {
+func (s *StateServiceImpl) GetStateById(id int, filer model.FilerProfile, explain bool) (*model.State, *apperrors.AppError) {
 	// retrieve state census information using the given id
 	sc, ok := s.stateIdMp[id]
 	if !ok {
 		logger.Warn("State id %v not in the cache", id)
 		return nil, apperrors.StateIDNotFound(id)
 	}
+	// resolve the tax year the estimate is made for
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, err
+	}
 	// process the yearly tax estimate given this income
 	logger.Info("Processing the tax liability for %v", id)
-	t, st, ft := s.processTaxLiabilityById(id, fs, dependents, income)
+	b, err := s.processTaxLiabilityById(id, filer, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.buildState(sc, t, st, ft), nil
+	return s.buildState(sc, filer, b, explain), nil
 }
 
-// helper method to construct state for given args
-func (s *StateServiceImpl) buildState(sc []interface{}, t, st, ft int) *model.State {
-	return &model.State{
+// helper method to construct state for given args, the breakdown is only attached if an explanation is requested
+func (s *StateServiceImpl) buildState(sc []interface{}, filer model.FilerProfile, b *model.TaxBreakdown, explain bool) *model.State {
+	state := &model.State{
 		State_id:   readAsInt(sc[CENSUS_STATE_ID]),
 		State_name: readAsString(sc[CENSUS_STATE_NAME]),
+		Tax_year:   filer.Tax_year,
+		Projected:  s.isProjected(filer.Tax_year),
 		// state level census metrics
 		Pop:           readAsInt(sc[STATE_POP]),
 		Male_pop:      readAsInt(sc[STATE_MALE_POP]),
@@ -204,46 +390,261 @@ func (s *StateServiceImpl) buildState(sc []interface{}, t, st, ft int) *model.St
 		Average_rent:  readAsInt(sc[STATE_AVERAGE_RENT]),
 		Commute:       readAsInt(sc[STATE_COMMUTE]),
 		// the tax metrics
-		Total_tax:   t,
-		State_tax:   st,
-		Federal_tax: ft,
+		Total_tax:   b.GetTotalTax(),
+		State_tax:   b.State.Tax,
+		Federal_tax: b.Federal.Tax,
+		// report when the state schedule for the filing status is not available
+		State_schedule_fallback: b.State.Schedule_fallback,
+	}
+
+	if b.Payroll != nil {
+		state.Payroll_tax = b.Payroll.Tax
+	}
+
+	// sales tax is estimated at the state rate alone
+	salesTax := s.getSalesTax(filer, state.State_id, 0, 0)
+	b.Sales_tax = &salesTax
+	state.Sales_tax = salesTax.Tax
+	state.Sales_tax_rate = salesTax.Combined_rate
+
+	state.Child_tax_credit = getCredit(b.Federal, model.ChildTaxCredit).Amount
+	state.Other_dependent_credit = getCredit(b.Federal, model.OtherDependentCredit).Amount
+	state.Earned_income_credit = getCredit(b.Federal, model.EarnedIncomeCredit).Amount
+	state.State_earned_income_credit = getCredit(b.State, model.StateEarnedIncomeCredit).Amount
+	state.Federal_deduction_type = b.Federal.Deduction_type
+	state.Net_investment_income_tax = b.Federal.Net_investment_income_tax
+	if b.Federal.Alternative_minimum_tax != nil {
+		state.Amt_tax = b.Federal.Alternative_minimum_tax.Tax
+	}
+	// refundable credits can exceed the taxes owed
+	state.Net_refund = state.Total_tax < 0
+	state.Disposable_income = model.GetDisposableIncome(b.Gross_income, state.Total_tax, b.Contributions.GetPayDeduction(), state.Average_rent)
+
+	// the self-employed pay estimated taxes through the year instead of withholding
+	if filer.Employment_type == model.SelfEmployed {
+		state.Quarterly_estimates = getQuarterlyEstimates(b)
+	}
+
+	if explain {
+		state.Breakdown = b
 	}
+
+	return state
 }
 
-// process state tax liability for a given id
-func (s *StateServiceImpl) processTaxLiabilityById(id int, fs model.FilingStatus, dependents int, income int) (int, int, int) {
-	ti := s.stateTaxIdMp[id]
-	return s.processTaxLiability(fs, dependents, income, ti)
+// process state tax liability for a given id, including the liability for a tax locale if one is given
+func (s *StateServiceImpl) processTaxLiabilityById(id int, filer model.FilerProfile, taxLocale *model.TaxLocaleInfo) (*model.TaxBreakdown, *apperrors.AppError) {
+	ti, ok := s.getTaxIdMp(filer.Tax_year)[id]
+	if !ok {
+		logger.Warn("State %v not found in the state tax cache", id)
+		return nil, apperrors.StateIDNotInTaxCache(id)
+	}
+
+	return s.processTaxLiability(filer, ti, taxLocale, nil)
 }
 
 // process state tax liability for a given name
-func (s *StateServiceImpl) processTaxLiabilityByName(name string, fs model.FilingStatus, dependents int, income int) (int, int, int) {
-	ti := s.stateTaxNameMp[name]
-	return s.processTaxLiability(fs, dependents, income, ti)
+func (s *StateServiceImpl) processTaxLiabilityByName(name string, filer model.FilerProfile) (*model.TaxBreakdown, *apperrors.AppError) {
+	ti, ok := s.getTaxNameMp(filer.Tax_year)[name]
+	if !ok {
+		logger.Warn("State %s not found in the state tax cache", name)
+		return nil, apperrors.StateNameNotInTaxCache(name)
+	}
+
+	return s.processTaxLiability(filer, ti, nil, nil)
 }
 
-// core logic to process state tax liability
-func (s *StateServiceImpl) processTaxLiability(fs model.FilingStatus, dependents int, income int, ti *model.StateTaxInfo) (int, int, int) {
-	// use filing status to determine state deduction and exemption
+// process the tax liability of a household living in the home state and locale whose earners each work in a state
+// and locale of their own. The household files one return, with local and payroll taxes charged per earner
+func (s *StateServiceImpl) processHouseholdTaxLiabilityById(homeId int, filer model.FilerProfile, homeLocale *model.TaxLocaleInfo,
+	earners []model.EarnerWork) (*model.TaxBreakdown, *apperrors.AppError) {
+	ti, ok := s.getTaxIdMp(filer.Tax_year)[homeId]
+	if !ok {
+		logger.Warn("State %v not found in the state tax cache", homeId)
+		return nil, apperrors.StateIDNotInTaxCache(homeId)
+	}
+
+	return s.processTaxLiability(filer, ti, homeLocale, earners)
+}
+
+// core logic to process state, local, federal, and payroll tax liability, returns the breakdown of each computation.
+// State and local tax are processed first so they can be deducted federally. Earners are given for households, whose
+// local and payroll taxes are charged on the wages of each earner. Returns an error if the work state of an earner is
+// not in the state tax cache
+func (s *StateServiceImpl) processTaxLiability(filer model.FilerProfile, ti *model.StateTaxInfo, taxLocale *model.TaxLocaleInfo,
+	earners []model.EarnerWork) (*model.TaxBreakdown, *apperrors.AppError) {
 	logger.Info("Processing state liability")
-	stateTax := 0
-	switch fs {
-	case model.Head, model.Single:
-		stateIncome := getTaxableIncome(income, ti.Single_deduction, ti.Single_exemption, dependents)
-		stateTax = ti.GetSingleTaxLiability(stateIncome)
-	case model.Married:
-		stateIncome := getTaxableIncome(income, ti.Married_deduction, ti.Married_exemption, dependents)
-		stateTax = ti.GetMarriedTaxLiability(stateIncome)
-	}
-	// apply the rate to the income for the state tax amount
+	income := filer.GetTotalIncome()
+	contributions := s.federalService.getPreTaxContributions(filer)
+	stateBreakdown := getStateBreakdown(filer, ti, contributions)
+	stateBreakdown.Tax_before_credits = stateBreakdown.Tax
+
+	// payroll is processed before federal tax so the deductible portion of self-employment tax can adjust federal income
+	var localeBreakdown *model.LocaleTaxBreakdown
+	var payrollBreakdown model.PayrollTaxBreakdown
+	var earnerBreakdowns []model.EarnerTaxBreakdown
+	if earners == nil {
+		if taxLocale != nil {
+			localeBreakdown = getLocaleTaxBreakdown(filer, contributions, stateBreakdown.Tax, *taxLocale, filer.Resident)
+		}
+		logger.Info("Processing payroll liability")
+		payrollBreakdown = s.federalService.getPayrollLiability(filer, contributions, 1)
+	} else {
+		logger.Info("Processing liability of each earner")
+		var err *apperrors.AppError
+		earnerBreakdowns, payrollBreakdown.Deductible_portion, err = s.getEarnerBreakdowns(filer, ti, contributions, &stateBreakdown, taxLocale, earners)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stateLocalTax := stateBreakdown.Tax
+	if localeBreakdown != nil {
+		stateLocalTax = stateLocalTax + localeBreakdown.Tax
+	}
+	for _, e := range earnerBreakdowns {
+		stateLocalTax = stateLocalTax + e.GetStateLocalTax()
+	}
+
 	logger.Info("Processing federal liability")
-	federalTax := s.federalService.getFederalLiability(fs, dependents, income)
+	federalBreakdown := s.federalService.getFederalLiability(filer, payrollBreakdown.Deductible_portion+contributions.GetWageReduction(true, true),
+		contributions.Ira, stateLocalTax)
+
+	// the state earned income credit is a percent of the federal credit
+	stateEitc := model.GetStateEarnedIncomeCredit(getCredit(federalBreakdown, model.EarnedIncomeCredit), ti.Eitc_percent, stateBreakdown.Tax)
+	stateBreakdown.Credits = append(stateBreakdown.Credits, stateEitc)
+	stateBreakdown.Tax = stateBreakdown.Tax - stateEitc.Amount
+
+	b := &model.TaxBreakdown{
+		Gross_income:  income,
+		Contributions: contributions,
+		Federal:       federalBreakdown,
+		State:         stateBreakdown,
+		Locale:        localeBreakdown,
+		Earners:       earnerBreakdowns,
+	}
+
+	if !filer.Exclude_payroll && earners == nil {
+		b.Payroll = &payrollBreakdown
+	}
 
-	return stateTax + federalTax, stateTax, federalTax
+	return b, nil
+}
+
+// helper method to compute the local and payroll taxes charged on the wages of each earner of a household, returns the
+// breakdown of each earner and the deductible portion of their self-employment tax. Earners who work across state lines
+// without a reciprocity agreement pay nonresident tax to the work state, which the home state credits in the given breakdown
+func (s *StateServiceImpl) getEarnerBreakdowns(filer model.FilerProfile, ti *model.StateTaxInfo, contributions model.PreTaxContributions,
+	stateBreakdown *model.IncomeTaxBreakdown, taxLocale *model.TaxLocaleInfo, earners []model.EarnerWork) ([]model.EarnerTaxBreakdown, int, *apperrors.AppError) {
+	wages := 0
+	for _, e := range earners {
+		wages = wages + e.Income
+	}
+	income := filer.GetTotalIncome()
+
+	breakdowns := []model.EarnerTaxBreakdown{}
+	otherStateCredits := []model.TaxCredit{}
+	deductible := 0
+	for _, e := range earners {
+		// the earner's portion of the household wages, contributions, and state tax
+		wageShare := 0.0
+		if wages > 0 {
+			wageShare = float64(e.Income) / float64(wages)
+		}
+		ef := filer
+		ef.Income = e.Income
+		ec := contributions.GetEarnerShare(wageShare)
+		homeStateTax := int(float64(stateBreakdown.Tax_before_credits) * wageShare)
+		eb := model.EarnerTaxBreakdown{Income: e.Income}
+
+		// the work state taxes the share of income earned there, and the home state credits the tax paid on
+		// income it also taxes
+		workStateTax := homeStateTax
+		if e.Work_state_id != ti.State_id && !s.hasReciprocity(ti.State_id, e.Work_state_id, filer) {
+			wti, ok := s.getTaxIdMp(filer.Tax_year)[e.Work_state_id]
+			if !ok {
+				logger.Warn("Work state %v not found in the state tax cache", e.Work_state_id)
+				return nil, 0, apperrors.StateIDNotInTaxCache(e.Work_state_id)
+			}
+			wb := getStateBreakdown(filer, wti, contributions)
+			wb.Tax_before_credits = wb.Tax
+			if income > 0 {
+				wb.Nonresident_share = float64(e.Income) / float64(income)
+			}
+			wb.Tax = int(float64(wb.Tax_before_credits) * wb.Nonresident_share)
+			credit := model.GetOtherStateCredit(wb.Tax, stateBreakdown.Tax_before_credits, wb.Nonresident_share)
+			eb.Work_state = &wb
+			eb.Other_state_credit = &credit
+			otherStateCredits = append(otherStateCredits, credit)
+			workStateTax = wb.Tax
+		}
+
+		// residents are taxed by the home locale, and nonresidents by a work locale outside it
+		if taxLocale != nil {
+			eb.Locale = getLocaleTaxBreakdown(ef, ec, homeStateTax, *taxLocale, true)
+		}
+		if e.Work_locale.Locale_id != 0 && (taxLocale == nil || e.Work_locale.Locale_id != taxLocale.Locale_id) {
+			eb.Work_locale = getLocaleTaxBreakdown(ef, ec, workStateTax, e.Work_locale, false)
+		}
+
+		// the additional medicare threshold of a joint return is split by the wages of each earner
+		payroll := s.federalService.getPayrollLiability(ef, ec, wageShare)
+		deductible = deductible + payroll.Deductible_portion
+		if !filer.Exclude_payroll {
+			eb.Payroll = &payroll
+		}
+
+		breakdowns = append(breakdowns, eb)
+	}
+
+	if len(otherStateCredits) > 0 {
+		credit := model.CombineCredits(model.OtherStateCredit, otherStateCredits)
+		stateBreakdown.Credits = append(stateBreakdown.Credits, credit)
+		stateBreakdown.Tax = stateBreakdown.Tax - credit.Amount
+	}
+
+	return breakdowns, deductible, nil
+}
+
+// helper function to compute the state income tax before credits using the filing status to determine the
+// deduction and exemption
+func getStateBreakdown(filer model.FilerProfile, ti *model.StateTaxInfo, contributions model.PreTaxContributions) model.IncomeTaxBreakdown {
+	income := filer.GetTotalIncome()
+	dependents := filer.Dependents
+	// states tax investment income as ordinary income, less any exclusion of long-term gains and the pre-tax
+	// contributions the state allows
+	exclusion := int(float64(filer.Long_term_gains)*ti.Capital_gains_exclusion) +
+		contributions.GetIncomeReduction(ti.Retirement_deductible, ti.Hsa_deductible)
+	var stateBreakdown model.IncomeTaxBreakdown
+	switch filer.Filing_status {
+	case model.Head:
+		stateBreakdown = getIncomeTaxBreakdown(income, exclusion, ti.Head_deduction, ti.Head_exemption, ti.Dependent_exemption, dependents)
+		stateBreakdown.Tax, stateBreakdown.Brackets = ti.GetHeadTaxLiability(stateBreakdown.Taxable_income)
+		stateBreakdown.Schedule = model.Head
+		if ti.Head_fallback {
+			stateBreakdown.Schedule = model.Single
+		}
+	// states group married filing separately with single filers
+	case model.Single, model.MarriedSeparately:
+		stateBreakdown = getIncomeTaxBreakdown(income, exclusion, ti.Single_deduction, ti.Single_exemption, ti.Dependent_exemption, dependents)
+		stateBreakdown.Tax, stateBreakdown.Brackets = ti.GetSingleTaxLiability(stateBreakdown.Taxable_income)
+		stateBreakdown.Schedule = model.Single
+	// states group qualifying surviving spouses with married filers
+	case model.Married, model.SurvivingSpouse:
+		stateBreakdown = getIncomeTaxBreakdown(income, exclusion, ti.Married_deduction, ti.Married_exemption, ti.Dependent_exemption, dependents)
+		stateBreakdown.Tax, stateBreakdown.Brackets = ti.GetMarriedTaxLiability(stateBreakdown.Taxable_income)
+		stateBreakdown.Schedule = model.Married
+	}
+	if stateBreakdown.Schedule != "" {
+		stateBreakdown.Deduction_type = model.StandardDeduction
+		stateBreakdown.Schedule_fallback = stateBreakdown.Schedule != filer.Filing_status
+	}
+
+	return stateBreakdown
 }
 
 // get census and tax information by name
-func (s *StateServiceImpl) GetStateByName(name string, fs model.FilingStatus, dependents int, income int) (*model.State, *apperrors.AppError) {
+func (s *StateServiceImpl) GetStateByName(name string, filer model.FilerProfile, explain bool) (*model.State, *apperrors.AppError) {
 	// retrieve state census information using the given name. Lowercase name first to match map.
 	name = strings.TrimSpace(strings.ToLower(name))
 	sc, ok := s.stateNameMp[name]
@@ -251,12 +652,93 @@ func (s *StateServiceImpl) GetStateByName(name string, fs model.FilingStatus, de
 		logger.Warn("State %s not in the cache", name)
 		return nil, apperrors.StateNameNotFound(name)
 	}
+	// resolve the tax year the estimate is made for
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, err
+	}
 
 	// process the yearly tax estimate given this income
 	logger.Info("Processing the tax liability for %s", name)
-	t, st, ft := s.processTaxLiabilityByName(name, fs, dependents, income)
+	b, err := s.processTaxLiabilityByName(name, filer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildState(sc, filer, b, explain), nil
+}
+
+// get the gross income needed in a state by id for the filer to take home a target net income. Returns false
+// if no income up to the max searched reaches the target
+func (s *StateServiceImpl) GetGrossUpById(id, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
 
-	return s.buildState(sc, t, st, ft), nil
+	logger.Info("Searching the gross incomes of state %v for a net income of %v", id, target)
+	return getGrossUp(target, tolerance, filer, s.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			return getStateSearchRegion(s.GetStateById(id, filer, true))
+		})
+}
+
+// get the gross income needed in a state by name for the filer to take home a target net income
+func (s *StateServiceImpl) GetGrossUpByName(name string, target, tolerance int, filer model.FilerProfile) (*model.GrossUp, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the gross incomes of %s for a net income of %v", name, target)
+	return getGrossUp(target, tolerance, filer, s.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			return getStateSearchRegion(s.GetStateByName(name, filer, true))
+		})
+}
+
+// get the lowest income in a state by id whose disposable income for the filer reaches that of the origin region,
+// estimated at the income of the filer. Returns false if no income up to the max searched reaches it
+func (s *StateServiceImpl) GetSalaryEquivalenceById(id int, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the incomes of state %v for a disposable income of %v", id, origin.Disposable_income)
+	return getSalaryEquivalence(origin, filer, s.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			return getStateSearchRegion(s.GetStateById(id, filer, true))
+		})
+}
+
+// get the lowest income in a state by name whose disposable income for the filer reaches that of the origin region
+func (s *StateServiceImpl) GetSalaryEquivalenceByName(name string, origin model.ComparisonRegion, filer model.FilerProfile) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	var err *apperrors.AppError
+	filer.Tax_year, err = s.getTaxYear(filer.Tax_year)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Searching the incomes of %s for a disposable income of %v", name, origin.Disposable_income)
+	return getSalaryEquivalence(origin, filer, s.isProjected(filer.Tax_year),
+		func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+			return getStateSearchRegion(s.GetStateByName(name, filer, true))
+		})
+}
+
+// helper function to reduce a state estimated with a breakdown to the region returned with a gross up or salary
+// equivalence
+func getStateSearchRegion(state *model.State, err *apperrors.AppError) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError) {
+	if err != nil {
+		return model.ComparisonRegion{}, nil, err
+	}
+
+	return model.GetStateComparisonRegion(state), state.Breakdown, nil
 }
 
 // get state for given metric and size
@@ -274,9 +756,14 @@ func (s *StateServiceImpl) GetStateList(metricName string, n int, desc bool) (*m
 
 }
 
-// get state tax info by id
-func (s *StateServiceImpl) GetStateTaxInfoById(id int) (*model.StateTaxInfo, *apperrors.AppError) {
-	res, ok := s.stateTaxIdMp[id]
+// get state tax info by id for a tax year, 0 for the default year
+func (s *StateServiceImpl) GetStateTaxInfoById(id, year int) (*model.StateTaxInfo, *apperrors.AppError) {
+	year, err := s.getTaxYear(year)
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := s.stateTaxIdMp[year][id]
 	if !ok {
 		logger.Warn("State %v not found in the state tax cache", id)
 		return nil, apperrors.StateIDNotInTaxCache(id)
@@ -284,10 +771,15 @@ func (s *StateServiceImpl) GetStateTaxInfoById(id int) (*model.StateTaxInfo, *ap
 	return res, nil
 }
 
-// get state tax info by name
-func (s *StateServiceImpl) GetStateTaxInfoByName(name string) (*model.StateTaxInfo, *apperrors.AppError) {
+// get state tax info by name for a tax year, 0 for the default year
+func (s *StateServiceImpl) GetStateTaxInfoByName(name string, year int) (*model.StateTaxInfo, *apperrors.AppError) {
+	year, err := s.getTaxYear(year)
+	if err != nil {
+		return nil, err
+	}
+
 	name = strings.TrimSpace(strings.ToLower(name))
-	res, ok := s.stateTaxNameMp[name]
+	res, ok := s.stateTaxNameMp[year][name]
 	if !ok {
 		logger.Warn("State %s not found in the state tax cache", name)
 		return nil, apperrors.StateNameNotInTaxCache(name)
@@ -297,10 +789,20 @@ func (s *StateServiceImpl) GetStateTaxInfoByName(name string) (*model.StateTaxIn
 
 }
 
+// whether wages earned in the work state are only taxed by the resident state under a reciprocity agreement.
+// Agreements cover the wages of employees, not self-employment earnings
+func (s *StateServiceImpl) hasReciprocity(residentId, workId int, filer model.FilerProfile) bool {
+	if filer.Employment_type == model.SelfEmployed {
+		return false
+	}
+
+	return s.reciprocityMp[residentId][workId]
+}
+
 // get the state name associated with an id
 func (s *StateServiceImpl) getStateNameById(id int) (string, *apperrors.AppError) {
 
-	res, ok := s.stateTaxIdMp[id]
+	res, ok := s.stateTaxIdMp[s.defaultYear][id]
 	if !ok {
 		logger.Warn("State %v not found in the state tax cache", id)
 		return "", apperrors.StateIDNotFound(id)
@@ -308,3 +810,55 @@ func (s *StateServiceImpl) getStateNameById(id int) (string, *apperrors.AppError
 
 	return res.State_name, nil
 }
+
+// resolve the requested tax year, 0 for the default year
+func (s *StateServiceImpl) getTaxYear(year int) (int, *apperrors.AppError) {
+	if year == 0 {
+		return s.defaultYear, nil
+	}
+
+	if _, ok := s.stateTaxIdMp[year]; !ok {
+		logger.Warn("Tax year %v not found in the state tax cache", year)
+		return 0, apperrors.TaxYearNotFound(year)
+	}
+
+	return year, nil
+}
+
+// get the sales tax of the filer at the combined rate of a state, county, and tax locale. Ids of 0 leave out the
+// rate of that area
+func (s *StateServiceImpl) getSalesTax(filer model.FilerProfile, stateId, countyId, localeId int) model.SalesTaxBreakdown {
+	rates := model.SalesTaxRates{State_rate: s.stateSalesTaxMp[stateId]}
+	if countyId != 0 {
+		rates.County_rate = s.countySalesTaxMp[countyId]
+	}
+	if localeId != 0 {
+		rates.Locale_rate = s.localeSalesTaxMp[localeId]
+	}
+
+	return model.GetSalesTaxBreakdown(filer, rates, consumptionShares)
+}
+
+// whether the tax tables of a year are projected from the latest published year
+func (s *StateServiceImpl) isProjected(year int) bool {
+	_, ok := s.stateTaxIdMp[year]
+	return ok && year > s.latestYear
+}
+
+// tax info of each state id for a tax year, falling back to the default year when it is not loaded
+func (s *StateServiceImpl) getTaxIdMp(year int) map[int]*model.StateTaxInfo {
+	if mp, ok := s.stateTaxIdMp[year]; ok {
+		return mp
+	}
+
+	return s.stateTaxIdMp[s.defaultYear]
+}
+
+// tax info of each state name for a tax year, falling back to the default year when it is not loaded
+func (s *StateServiceImpl) getTaxNameMp(year int) map[string]*model.StateTaxInfo {
+	if mp, ok := s.stateTaxNameMp[year]; ok {
+		return mp
+	}
+
+	return s.stateTaxNameMp[s.defaultYear]
+}
@@ -1,12 +1,234 @@
 package services
 
+import (
+	"math"
+	"time"
+
+	"github.com/Matthew-Curry/re-region-api/src/apperrors"
+	"github.com/Matthew-Curry/re-region-api/src/model"
+)
+
+// number of years after the latest published tax year that are projected for inflation
+const PROJECTED_YEARS int = 3
+
+// function used by both the state and federal services to pick the default tax year, the current
+// year when it is loaded, else the latest loaded year
+func getDefaultTaxYear(years []int) int {
+	current := time.Now().Year()
+	for _, y := range years {
+		if y == current {
+			return y
+		}
+	}
+
+	return getLatestTaxYear(years)
+}
+
+// function used by both the state and federal services to get the latest published tax year
+func getLatestTaxYear(years []int) int {
+	latest := 0
+	for _, y := range years {
+		if y > latest {
+			latest = y
+		}
+	}
+
+	return latest
+}
+
+// function used by both the state and federal services to get the cumulative cpi factor of a year projected
+// the given number of years past the latest published year
+func getCumulativeCpiFactor(cpiFactor float64, years int) float64 {
+	return math.Pow(1+cpiFactor, float64(years))
+}
+
 // function used by both the state and federal services to get
-// taxable income based on income, deductions, exemptions, and dependents
-func getTaxableIncome(income, deduction, exemptions, dependents int) int{
-	income = income - deduction - dependents*exemptions
+// taxable income based on income, adjustments, deductions, exemptions, and dependents
+func getTaxableIncome(income, adjustments, deduction, exemption, dependentExemption, dependents int) int {
+	income = income - adjustments - deduction - exemption - dependents*dependentExemption
 	if income < 0 {
 		return 0
 	}
 
-	return income 
-}
\ No newline at end of file
+	return income
+}
+
+// function used by both the state and federal services to record the inputs to the taxable income
+// in a breakdown. Callers populate the brackets and tax from the taxable income
+func getIncomeTaxBreakdown(income, adjustments, deduction, exemption, dependentExemption, dependents int) model.IncomeTaxBreakdown {
+	return model.IncomeTaxBreakdown{
+		Adjustments:         adjustments,
+		Deduction:           deduction,
+		Personal_exemption:  exemption,
+		Dependents:          dependents,
+		Dependent_exemption: dependentExemption,
+		Taxable_income:      getTaxableIncome(income, adjustments, deduction, exemption, dependentExemption, dependents),
+	}
+}
+
+// function used by the state service to get the liability for a tax locale from the rate, flat fees, and
+// piggyback on the state tax that apply to the given residency status
+func getLocaleTaxBreakdown(filer model.FilerProfile, contributions model.PreTaxContributions, stateTax int, taxLocale model.TaxLocaleInfo, resident bool) *model.LocaleTaxBreakdown {
+	// use the residency status to determine the local rate and fees
+	var lb *model.LocaleTaxBreakdown
+	if resident {
+		logger.Info("Getting resident county liability")
+		lb = &model.LocaleTaxBreakdown{
+			Resident:       true,
+			Rate:           taxLocale.Resident_rate,
+			Month_fee:      taxLocale.Resident_month_fee,
+			Year_fee:       taxLocale.Resident_year_fee,
+			Pay_period_fee: taxLocale.Resident_pay_period_fee,
+			State_rate:     taxLocale.Resident_state_rate,
+		}
+	} else {
+		logger.Info("Getting non-resident county liability")
+		lb = &model.LocaleTaxBreakdown{
+			Resident:       false,
+			Rate:           taxLocale.Nonresident_rate,
+			Month_fee:      taxLocale.Nonresident_month_fee,
+			Year_fee:       taxLocale.Nonresident_year_fee,
+			Pay_period_fee: taxLocale.Nonresident_pay_period_fee,
+			State_rate:     taxLocale.Nonresident_state_rate,
+		}
+	}
+
+	// local tax is the rate applied to earned income, flat fees over the year, and any piggyback on the state tax. Locales
+	// that only tax wages do not charge their rate and fees on self-employment earnings
+	lb.Pay_periods = filer.Pay_frequency.GetPayPeriods()
+	lb.Self_employment_exempt = filer.Employment_type == model.SelfEmployed && !taxLocale.Self_employment_taxed
+	if !lb.Self_employment_exempt {
+		lb.Taxable_wages = filer.Income - contributions.GetWageReduction(taxLocale.Retirement_deductible, taxLocale.Hsa_deductible)
+		lb.Rate_tax = int(float64(lb.Taxable_wages) * lb.Rate)
+		lb.Fee_tax = int(12*lb.Month_fee) + int(lb.Year_fee) + int(lb.Pay_period_fee*float64(lb.Pay_periods))
+	}
+	lb.State_rate_tax = int(float64(stateTax) * lb.State_rate)
+	lb.Tax = lb.Rate_tax + lb.Fee_tax + lb.State_rate_tax
+
+	return lb
+}
+
+// function used by the state and county services to pull a credit out of a breakdown, an empty credit
+// is returned when the credit was not applied
+func getCredit(b model.IncomeTaxBreakdown, name string) model.TaxCredit {
+	for _, c := range b.Credits {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return model.TaxCredit{Name: name}
+}
+
+// quarter number, due date pairs for estimated tax payments
+var estimatedPaymentDueDates = []string{"April 15", "June 15", "September 15", "January 15"}
+
+// function used by the state and county services to split an annual estimate into quarterly estimated
+// payments. Any remainder from splitting evenly is paid with the last quarter
+func getQuarterlyEstimates(b *model.TaxBreakdown) []model.EstimatedPayment {
+	federal := b.Federal.Tax
+	if b.Payroll != nil {
+		federal = federal + b.Payroll.Tax
+	}
+	state := b.State.Tax
+	locale := 0
+	if b.Locale != nil {
+		locale = b.Locale.Tax
+	}
+	// refunds are claimed when filing, so nothing is estimated for a level that nets to a refund
+	if federal < 0 {
+		federal = 0
+	}
+	if state < 0 {
+		state = 0
+	}
+
+	payments := []model.EstimatedPayment{}
+	n := len(estimatedPaymentDueDates)
+	for i, dueDate := range estimatedPaymentDueDates {
+		p := model.EstimatedPayment{
+			Quarter:        i + 1,
+			Due_date:       dueDate,
+			Federal_amount: federal / n,
+			State_amount:   state / n,
+			Locale_amount:  locale / n,
+		}
+		if i == n-1 {
+			p.Federal_amount = federal - (n-1)*(federal/n)
+			p.State_amount = state - (n-1)*(state/n)
+			p.Locale_amount = locale - (n-1)*(locale/n)
+		}
+		p.Amount = p.Federal_amount + p.State_amount + p.Locale_amount
+		payments = append(payments, p)
+	}
+
+	return payments
+}
+
+// function used by the state and county services to find the gross income at which the filer takes home the target
+// net income in a region. Each income is estimated with a breakdown, so the net income is taken less the pre-tax
+// contributions left after their limits rather than those requested
+func getGrossUp(target, tolerance int, filer model.FilerProfile, projected bool,
+	estimateAt func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError)) (*model.GrossUp, bool, *apperrors.AppError) {
+	netAt := func(income int) (int, *apperrors.AppError) {
+		filer.Income = income
+		region, b, err := estimateAt(filer)
+		if err != nil {
+			return 0, err
+		}
+		return getNetIncome(income, region, b), nil
+	}
+	grossIncome, found, err := model.GetGrossIncome(target, netAt)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	// the region is returned as estimated at the gross income found
+	filer.Income = grossIncome
+	region, b, err := estimateAt(filer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return model.GetGrossUp(region, target, grossIncome, getNetIncome(grossIncome, region, b), tolerance, filer.Tax_year, projected), true, nil
+}
+
+// function used by the state and county services to find the lowest income in a destination region whose disposable
+// income reaches that of the origin region, estimated for the filer at the origin income. Disposable income is taken
+// less the pre-tax contributions left after their limits, as the net income of a gross up is
+func getSalaryEquivalence(origin model.ComparisonRegion, filer model.FilerProfile, projected bool,
+	estimateAt func(filer model.FilerProfile) (model.ComparisonRegion, *model.TaxBreakdown, *apperrors.AppError)) (*model.SalaryEquivalence, bool, *apperrors.AppError) {
+	originIncome := filer.Income
+	disposableAt := func(income int) (int, *apperrors.AppError) {
+		filer.Income = income
+		region, _, err := estimateAt(filer)
+		if err != nil {
+			return 0, err
+		}
+		return region.Disposable_income, nil
+	}
+	equivalentIncome, found, err := model.GetEquivalentIncome(origin.Disposable_income, originIncome, disposableAt)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	// the destination is returned as estimated at the equivalent income found
+	filer.Income = equivalentIncome
+	destination, _, err := estimateAt(filer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return model.GetSalaryEquivalence(origin, destination, originIncome, equivalentIncome, filer.Tax_year, projected), true, nil
+}
+
+// helper function to get the net income of a region estimated with a breakdown. A county without tax locales has
+// no breakdown, and nothing is taken from pay
+func getNetIncome(income int, region model.ComparisonRegion, b *model.TaxBreakdown) int {
+	contributions := 0
+	if b != nil {
+		contributions = b.Contributions.GetPayDeduction()
+	}
+
+	return model.GetNetIncome(income, region.Total_tax, contributions)
+}
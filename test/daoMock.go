@@ -31,18 +31,51 @@ func (d *DaoMock) GetCountyList(metric string, n int, desc bool) ([][]interface{
 	return res, nil
 }
 
-func (d *DaoMock) GetStateTax() ([][]interface{}, *apperrors.AppError) {
+func (d *DaoMock) GetStateTax(year int) ([][]interface{}, *apperrors.AppError) {
 	res := make([][]interface{}, 0)
+	if year == 2020 {
+		return res, nil
+	}
 
 	f1 := append(make([]uint8, 0), 48, 46, 48, 50)
 	f2 := append(make([]uint8, 0), 48, 46, 49, 50)
+	f3 := append(make([]uint8, 0), 48, 46, 51, 48)
+	f4 := append(make([]uint8, 0), 48, 46, 48, 48)
 
-	a1 := append(make([]interface{}, 0), 36, "New York", 2500, 7500, 1500, 3000, 1000, f1, 0, f1, 0)
+	a1 := append(make([]interface{}, 0), 36, "New York", 2500, 7500, 4000, 1500, 3000, 1500, 1000, f1, 0, f1, 0, f1, 0, false, f3, f4, true, true, true)
 	res = append(res, a1)
 
-	a2 := append(make([]interface{}, 0), 36, "New York", 2500, 7500, 1500, 3000, 1000, f2, 500, f2, 1000)
+	a2 := append(make([]interface{}, 0), 36, "New York", 2500, 7500, 4000, 1500, 3000, 1500, 1000, f2, 500, f2, 1000, f2, 750, false, f3, f4, true, true, true)
 	res = append(res, a2)
 
+	a3 := append(make([]interface{}, 0), 34, "New Jersey", 0, 0, 0, 1000, 2000, 1000, 1500, f1, 0, f1, 0, f1, 0, false, f4, f4, true, true, false)
+	res = append(res, a3)
+
+	f5 := append(make([]uint8, 0), 48, 46, 48, 51)
+	a4 := append(make([]interface{}, 0), 42, "Pennsylvania", 0, 0, 0, 0, 0, 0, 0, f5, 0, f5, 0, f5, 0, false, f4, f4, true, true, false)
+	res = append(res, a4)
+
+	return res, nil
+}
+
+func (d *DaoMock) GetStateReciprocity() ([][]interface{}, *apperrors.AppError) {
+	res := make([][]interface{}, 0)
+
+	nj := append(make([]interface{}, 0), 34, 42)
+	pa := append(make([]interface{}, 0), 42, 34)
+	res = append(res, nj, pa)
+
+	return res, nil
+}
+
+func (d *DaoMock) GetSalesTaxRates() ([][]interface{}, *apperrors.AppError) {
+	res := make([][]interface{}, 0)
+
+	ny := append(make([]interface{}, 0), 36, 0, 0, []uint8("0.04"))
+	nyc := append(make([]interface{}, 0), 36, 36061, 3376, []uint8("0.04875"))
+	nj := append(make([]interface{}, 0), 34, 0, 0, []uint8("0.06625"))
+	res = append(res, ny, nyc, nj)
+
 	return res, nil
 }
 
@@ -54,29 +87,121 @@ func (d *DaoMock) GetCountyDataById(county_id int) ([][]interface{}, *apperrors.
 	return getMockCounty()
 }
 
-func (d *DaoMock) GetFederalTaxData() ([][]interface{}, *apperrors.AppError) {
+func (d *DaoMock) GetAllCountyData() ([][]interface{}, *apperrors.AppError) {
+	res, _ := getMockCounty()
+
+	// a county without a tax locale, which has lower taxes and rent than New York County
+	f := append(make([]uint8, 0), 48, 46, 48, 48)
+	propertyTaxRate := []uint8("0.0162")
+
+	westchester := append(make([]interface{}, 0), 36119, "Westchester County", 36, 1004457, 485006, 519451, 105387, 1600, 31, propertyTaxRate, 500000, 0, "", "", f, f, f, f, f, "", f, f, f, f, f, true, true, true)
+	res = append(res, westchester)
+
+	return res, nil
+}
+
+func (d *DaoMock) GetFederalTaxData(year int) ([][]interface{}, *apperrors.AppError) {
 	res := make([][]interface{}, 0)
 
 	f1 := append(make([]uint8, 0), 48, 46, 49, 48)
 	f2 := append(make([]uint8, 0), 48, 46, 49, 50)
 	f3 := append(make([]uint8, 0), 48, 46, 50, 50)
 
-	b1 := append(make([]interface{}, 0), f1, 0, 0, 0, 12950, 25900, 19400)
-	b2 := append(make([]interface{}, 0), f2, 10275, 20550, 14650, 12950, 25900, 19400)
-	b3 := append(make([]interface{}, 0), f3, 41775, 83550, 55900, 12950, 25900, 19400)
+	// the prior year has its own brackets and deductions
+	if year == 2021 {
+		b1 := append(make([]interface{}, 0), f1, 0, 0, 0, 0, 0, 12550, 25100, 18800, 12550, 25100)
+		b2 := append(make([]interface{}, 0), f2, 9950, 19900, 14200, 9950, 19900, 12550, 25100, 18800, 12550, 25100)
+		b3 := append(make([]interface{}, 0), f3, 40525, 81050, 54200, 40525, 81050, 12550, 25100, 18800, 12550, 25100)
+		res = append(res, b1, b2, b3)
+
+		return res, nil
+	}
+
+	b1 := append(make([]interface{}, 0), f1, 0, 0, 0, 0, 0, 12950, 25900, 19400, 12950, 25900)
+	b2 := append(make([]interface{}, 0), f2, 10275, 20550, 14650, 10275, 20550, 12950, 25900, 19400, 12950, 25900)
+	b3 := append(make([]interface{}, 0), f3, 41775, 83550, 55900, 41775, 83550, 12950, 25900, 19400, 12950, 25900)
 
 	res = append(res, b1, b2, b3)
 
 	return res, nil
 }
 
+func (d *DaoMock) GetFederalParameters(year int) ([][]interface{}, *apperrors.AppError) {
+	res := make([][]interface{}, 0)
+
+	// thresholds by filing status are ordered single, married, head, separate, surviving
+	switch year {
+	case 2021:
+		// the expanded child credit of the prior year is fully refundable
+		p := append(make([]interface{}, 0), []uint8("0.062"), 142800, []uint8("0.0145"), []uint8("0.009"),
+			3000, 3000, []uint8("1"), 0, 500, 50, 1000, 200000, 400000, 10000, []uint8("0.15"), []uint8("0.20"), []uint8("0.038"),
+			19500, 6000, 3600, 7200, 10000, 5000, []uint8("0.075"), []uint8("1"), []uint8("0.26"), []uint8("0.28"), []uint8("0.25"),
+			200000, 250000, 200000, 125000, 200000,
+			40400, 80800, 54100, 40400, 80800,
+			445850, 501600, 473750, 250800, 501600,
+			200000, 250000, 200000, 125000, 250000,
+			73600, 114600, 73600, 57300, 114600,
+			523600, 1047200, 523600, 523600, 1047200,
+			199900, 199900, 199900, 99950, 199900)
+		res = append(res, p)
+	case 2022:
+		p := append(make([]interface{}, 0), []uint8("0.062"), 147000, []uint8("0.0145"), []uint8("0.009"),
+			2000, 1500, []uint8("0.15"), 2500, 500, 50, 1000, 200000, 400000, 10300, []uint8("0.15"), []uint8("0.20"), []uint8("0.038"),
+			20500, 6000, 3650, 7300, 10000, 5000, []uint8("0.075"), []uint8("0.6"), []uint8("0.26"), []uint8("0.28"), []uint8("0.25"),
+			200000, 250000, 200000, 125000, 200000,
+			41675, 83350, 55800, 41675, 83350,
+			459750, 517200, 488500, 258600, 517200,
+			200000, 250000, 200000, 125000, 250000,
+			75900, 118100, 75900, 59050, 118100,
+			539900, 1079800, 539900, 539900, 1079800,
+			206100, 206100, 206100, 103050, 206100)
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+func (d *DaoMock) GetEarnedIncomeCreditSchedules(year int) ([][]interface{}, *apperrors.AppError) {
+	res := make([][]interface{}, 0)
+
+	switch year {
+	case 2021:
+		s0 := append(make([]interface{}, 0), 0, []uint8("0.153"), 1502, []uint8("0.153"), 11610, 17560)
+		s1 := append(make([]interface{}, 0), 1, []uint8("0.34"), 3618, []uint8("0.1598"), 19520, 25470)
+		s2 := append(make([]interface{}, 0), 2, []uint8("0.40"), 5980, []uint8("0.2106"), 19520, 25470)
+		s3 := append(make([]interface{}, 0), 3, []uint8("0.45"), 6728, []uint8("0.2106"), 19520, 25470)
+		res = append(res, s0, s1, s2, s3)
+	case 2022:
+		s0 := append(make([]interface{}, 0), 0, []uint8("0.0765"), 560, []uint8("0.0765"), 9160, 15290)
+		s1 := append(make([]interface{}, 0), 1, []uint8("0.34"), 3733, []uint8("0.1598"), 20130, 26260)
+		s2 := append(make([]interface{}, 0), 2, []uint8("0.40"), 6164, []uint8("0.2106"), 20130, 26260)
+		s3 := append(make([]interface{}, 0), 3, []uint8("0.45"), 6935, []uint8("0.2106"), 20130, 26260)
+		res = append(res, s0, s1, s2, s3)
+	}
+
+	return res, nil
+}
+
+func (d *DaoMock) GetTaxYears() ([][]interface{}, *apperrors.AppError) {
+	res := make([][]interface{}, 0)
+
+	// 2020 has federal brackets but no federal parameters or state brackets
+	y0 := append(make([]interface{}, 0), 2020)
+	y1 := append(make([]interface{}, 0), 2021)
+	y2 := append(make([]interface{}, 0), 2022)
+	res = append(res, y0, y1, y2)
+
+	return res, nil
+}
+
 // used by get county by name and id to return the same mock county
 func getMockCounty() ([][]interface{}, *apperrors.AppError) {
 	res := make([][]interface{}, 0)
 
 	f := append(make([]uint8, 0), 48, 46, 48, 48)
+	propertyTaxRate := []uint8("0.0088")
 
-	ny := append(make([]interface{}, 0), 36061, "New York County", 36, 1628706, 771278, 857428, 93651, 1753, 81, 3376, "New York City", "3.078% - 3.876%", f, f, f, f, f, "0.00%", f, f, f, f, f)
+	ny := append(make([]interface{}, 0), 36061, "New York County", 36, 1628706, 771278, 857428, 93651, 1753, 81, propertyTaxRate, 1000000, 3376, "New York City", "3.078% - 3.876%", f, f, f, f, f, "0.00%", f, f, f, f, f, true, true, true)
 	res = append(res, ny)
 
 	return res, nil
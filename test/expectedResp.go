@@ -9,10 +9,20 @@ import  (
 var tl = append(make([]model.TaxLocale,0), model.TaxLocale{
 	Locale_id:   3376,
 	Locale_name: "New York City",
-	Total_tax:   0,
-	Federal_tax: 0,
-	State_tax:   0,
+	Total_tax:   5267,
+	Federal_tax: -2287,
+	State_tax:   4112,
 	Locale_tax:  0,
+	Payroll_tax: 3442,
+	Sales_tax:      1309,
+	Sales_tax_rate: 0.04 + 0.04875,
+	Child_tax_credit:           4000,
+	Other_dependent_credit:     1000,
+	Earned_income_credit:       927,
+	State_earned_income_credit: 278,
+	Federal_deduction_type:     model.StandardDeduction,
+	Disposable_income: model.DisposableIncome{Gross_income: 45000, Total_tax: 5267, Annual_rent: 21036, Disposable_income: 18697,
+		Rent_to_income: 0.4675, Tax_to_income: 0.117},
 })
 
 var exCounty = &model.County{
@@ -20,12 +30,17 @@ var exCounty = &model.County{
 	County_name:   "New York County",
 	State_id:      36,
 	State_name:    "New York",
+	Tax_year:      2022,
 	Pop:           1628706,
 	Male_pop:      771278,
 	Female_pop:    857428,
 	Median_income: 93651,
 	Average_rent:  1753,
 	Commute:       81,
+	Median_home_value: 1000000,
+	Property_tax_rate: 0.0088,
+	Home_value:        1000000,
+	Property_tax:      8800,
 	Tax_locale:    tl,
 }
 var cmp = model.CountyMetricPair{
@@ -58,6 +73,9 @@ var tli = append(make([]model.TaxLocaleInfo,0), model.TaxLocaleInfo{
 	Nonresident_year_fee:    0,
 	Nonresident_pay_period_fee: 0,
 	Nonresident_state_rate:    0,
+	Self_employment_taxed: true,
+	Retirement_deductible: true,
+	Hsa_deductible:        true,
 })
 
 var exCountyTaxList = &model.CountyTaxList{
@@ -71,15 +89,27 @@ var exCountyTaxList = &model.CountyTaxList{
 var exState = &model.State{
 	State_id   :36,
 	State_name :"New York",
+	Tax_year   :2022,
 	Pop           :18466230,
 	Male_pop      :8953064,
 	Female_pop    :9513166,
 	Median_income :77578,
 	Average_rent  :1381,
 	Commute       :17,
-	Total_tax   :0,
-	State_tax   :0,
-	Federal_tax :0,
+	Total_tax   :-1503,
+	State_tax   :2544,
+	Federal_tax :-7489,
+	Payroll_tax :3442,
+	Sales_tax      :590,
+	Sales_tax_rate :0.04,
+	Child_tax_credit           :5410,
+	Other_dependent_credit     :1000,
+	Earned_income_credit       :2989,
+	State_earned_income_credit :896,
+	Federal_deduction_type     :model.StandardDeduction,
+	Net_refund                 :true,
+	Disposable_income :model.DisposableIncome{Gross_income: 45000, Total_tax: -1503, Annual_rent: 16572, Disposable_income: 29931,
+		Rent_to_income: 0.3683, Tax_to_income: -0.0334},
 }
 
 var mpList = model.StateMetricPair{
@@ -95,6 +125,8 @@ var bracket1 = model.StateBracket {
 	Single_bracket  :0,
 	Married_rate    :0.02,
 	Married_bracket :0,
+	Head_rate       :0.02,
+	Head_bracket    :0,
 }
 
 var bracket2 = model.StateBracket {
@@ -102,26 +134,42 @@ var bracket2 = model.StateBracket {
 	Single_bracket  :500,
 	Married_rate    :0.12,
 	Married_bracket :1000,
+	Head_rate       :0.12,
+	Head_bracket    :750,
 }
 
 var exStateTaxInfoId = &model.StateTaxInfo{
+	Tax_year   :2022,
 	State_id   :36,
 	State_name :"New York",
 	Single_deduction    :2500,
 	Married_deduction   :7500,
+	Head_deduction      :4000,
 	Single_exemption    :1500,
 	Married_exemption   :3000,
+	Head_exemption      :1500,
 	Dependent_exemption :1000,
+	Eitc_percent        :0.3,
+	Retirement_deductible :true,
+	Hsa_deductible        :true,
+	Indexed               :true,
 }
 
 var exStateTaxInfoName = &model.StateTaxInfo{
+	Tax_year   :2022,
 	State_id   :36,
 	State_name :"New York",
 	Single_deduction    :2500,
 	Married_deduction   :7500,
+	Head_deduction      :4000,
 	Single_exemption    :1500,
 	Married_exemption   :3000,
+	Head_exemption      :1500,
 	Dependent_exemption :1000,
+	Eitc_percent        :0.3,
+	Retirement_deductible :true,
+	Hsa_deductible        :true,
+	Indexed               :true,
 }
 
 var fb1 = model.FederalBracket{
@@ -129,6 +177,8 @@ var fb1 = model.FederalBracket{
 	Single_bracket  :0,
 	Married_bracket :0,
 	Head_bracket    :0,
+	Separate_bracket  :0,
+	Surviving_bracket :0,
 }
 
 var fb2 = model.FederalBracket{
@@ -136,6 +186,8 @@ var fb2 = model.FederalBracket{
 	Single_bracket  :10275,
 	Married_bracket :20550,
 	Head_bracket    :14650,
+	Separate_bracket  :10275,
+	Surviving_bracket :20550,
 }
 
 var fb3 = model.FederalBracket{
@@ -143,11 +195,191 @@ var fb3 = model.FederalBracket{
 	Single_bracket  :41775,
 	Married_bracket :83550,
 	Head_bracket    :55900,
+	Separate_bracket  :41775,
+	Surviving_bracket :83550,
 }
 
 
 var federalTaxInfo = &model.FederalTaxInfo{
+	Tax_year          :2022,
 	Single_deduction  :12950,
 	Married_deduction :25900,
 	Head_deduction    :19400,
-}
\ No newline at end of file
+	Separate_deduction  :12950,
+	Surviving_deduction :25900,
+}
+
+var exSingleBrackets = []model.BracketLiability{
+	{Rate: 0.1, Lower_bound: 0, Upper_bound: 10275, Taxed_income: 10275, Tax: 1027},
+	{Rate: 0.12, Lower_bound: 10275, Upper_bound: 41775, Taxed_income: 31500, Tax: 3780},
+	{Rate: 0.22, Lower_bound: 41775, Upper_bound: 0, Taxed_income: 8225, Tax: 1809},
+}
+
+var exStateProgressive = &model.State{
+	State_id   :36,
+	State_name :"New York",
+	Tax_year   :2022,
+	Pop           :18466230,
+	Male_pop      :8953064,
+	Female_pop    :9513166,
+	Median_income :77578,
+	Average_rent  :1381,
+	Commute       :17,
+	Total_tax   :26,
+	State_tax   :522,
+	Federal_tax :-496,
+	Earned_income_credit       :496,
+	State_earned_income_credit :148,
+	Federal_deduction_type     :model.StandardDeduction,
+	Sales_tax      :140,
+	Sales_tax_rate :0.04,
+	Disposable_income :model.DisposableIncome{Gross_income: 10000, Total_tax: 26, Annual_rent: 16572, Disposable_income: -6598,
+		Rent_to_income: 1.6572, Tax_to_income: 0.0026},
+}
+
+var exStateBreakdown = &model.TaxBreakdown{
+	Gross_income: 10000,
+	Federal: model.IncomeTaxBreakdown{
+		Deduction_type:      model.StandardDeduction,
+		Itemized_deductions: &model.ItemizedDeductions{Salt_paid: 430, Salt: 430, Total: 430},
+		Deduction:      12950,
+		Taxable_income: 0,
+		Brackets:       []model.BracketLiability{},
+		Alternative_minimum_tax: &model.AlternativeMinimumTaxBreakdown{Amt_income: 10000, Exemption: 75900},
+		Credits: []model.TaxCredit{
+			{Name: model.ChildTaxCredit},
+			{Name: model.OtherDependentCredit, Qualifying: 2, Base_credit: 1000, Phase_out: 1000},
+			{Name: model.EarnedIncomeCredit, Base_credit: 560, Phase_out: 496, Refundable: 496, Amount: 496},
+		},
+		Tax:      -496,
+		Schedule: model.Single,
+	},
+	State: model.IncomeTaxBreakdown{
+		Deduction_type:      model.StandardDeduction,
+		Deduction:           2500,
+		Personal_exemption:  1500,
+		Dependents:          2,
+		Dependent_exemption: 1000,
+		Taxable_income:      4000,
+		Brackets: []model.BracketLiability{
+			{Rate: 0.02, Lower_bound: 0, Upper_bound: 500, Taxed_income: 500, Tax: 10},
+			{Rate: 0.12, Lower_bound: 500, Upper_bound: 0, Taxed_income: 3500, Tax: 420},
+		},
+		Tax_before_credits: 430,
+		Credits: []model.TaxCredit{
+			{Name: model.StateEarnedIncomeCredit, Base_credit: 148, Phase_out: 148, Nonrefundable: 148, Amount: 148},
+		},
+		Tax: 282,
+		Schedule: model.Single,
+	},
+	Sales_tax: &model.SalesTaxBreakdown{
+		State_rate:         0.04,
+		Combined_rate:      0.04,
+		Spending_estimated: true,
+		Taxable_spending:   3500,
+		Tax:                140,
+	},
+}
+
+var exPayrollBreakdown = &model.PayrollTaxBreakdown{
+	Wages:                         210000,
+	Social_security_wages:         147000,
+	Social_security_tax:           9114,
+	Medicare_tax:                  3045,
+	Additional_medicare_threshold: 200000,
+	Additional_medicare_tax:       90,
+	Tax:                           12249,
+}
+
+var exQuarterlyEstimates = []model.EstimatedPayment{
+	{Quarter: 1, Due_date: "April 15", Federal_amount: 2720, State_amount: 1367, Locale_amount: 0, Amount: 4087},
+	{Quarter: 2, Due_date: "June 15", Federal_amount: 2720, State_amount: 1367, Locale_amount: 0, Amount: 4087},
+	{Quarter: 3, Due_date: "September 15", Federal_amount: 2720, State_amount: 1367, Locale_amount: 0, Amount: 4087},
+	{Quarter: 4, Due_date: "January 15", Federal_amount: 2720, State_amount: 1369, Locale_amount: 0, Amount: 4089},
+}
+
+var exChildTaxCredit = model.TaxCredit{
+	Name:          model.ChildTaxCredit,
+	Qualifying:    2,
+	Base_credit:   4000,
+	Phase_out:     4000,
+	Nonrefundable: 1840,
+	Refundable:    2160,
+	Amount:        4000,
+}
+
+var exPreferentialBrackets = []model.BracketLiability{
+	{Rate: 0, Lower_bound: 0, Upper_bound: 41675, Taxed_income: 4625, Tax: 0},
+	{Rate: 0.15, Lower_bound: 41675, Upper_bound: 459750, Taxed_income: 295375, Tax: 44306},
+}
+
+var exPreTaxContributions = model.PreTaxContributions{
+	Retirement:  20500,
+	Hsa:         3650,
+	Section_125: 2000,
+	Excess:      5850,
+}
+
+var exAlternativeMinimumTax = &model.AlternativeMinimumTaxBreakdown{
+	Amt_income:            320000,
+	Exemption:             75900,
+	Taxable_income:        244100,
+	Tentative_minimum_tax: 64226,
+	Regular_tax:           63816,
+	Tax:                   410,
+}
+
+var exPaycheckLocale = model.PaycheckLocale{
+	Locale_id:             3376,
+	Locale_name:           "New York City",
+	Gross_pay:             1000,
+	Pre_tax_contributions: 100,
+	Federal_withholding:   74.15,
+	State_withholding:     97.81,
+	Local_withholding:     0,
+	Payroll_withholding:   76.5,
+	Net_pay:               651.54,
+}
+
+var exCommuteLocale = model.CommuteLocale{
+	Home_locale_id:     3376,
+	Home_locale_name:   "New York City",
+	Work_locale_id:     3400,
+	Work_locale_name:   "Jersey City",
+	Total_tax:          18337,
+	Federal_tax:        9267,
+	Home_state_tax:     7286,
+	Work_state_tax:     1184,
+	Other_state_credit: 1184,
+	Home_locale_tax:    0,
+	Work_locale_tax:    600,
+	Payroll_tax:        0,
+}
+
+var exHouseholdEarners = []model.EarnerTax{
+	{
+		Income:           150000,
+		Work_county_id:   36061,
+		Work_county_name: "New York County",
+		Work_state_id:    36,
+		Work_state_name:  "New York",
+		Work_locale_id:   3376,
+		Work_locale_name: "New York City",
+		Payroll_tax:      11389,
+		Total_tax:        11389,
+	},
+	{
+		Income:             120000,
+		Work_county_id:     34017,
+		Work_county_name:   "Hudson County",
+		Work_state_id:      34,
+		Work_state_name:    "New Jersey",
+		Work_locale_id:     3400,
+		Work_locale_name:   "Jersey City",
+		Work_state_tax:     2382,
+		Other_state_credit: 2382,
+		Work_locale_tax:    1252,
+		Payroll_tax:        9260,
+		Total_tax:          12894,
+	},
+}
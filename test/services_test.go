@@ -12,7 +12,7 @@ import (
 
 	"testing"
 
-	_ "github.com/Matthew-Curry/re-region-api/src/model"
+	"github.com/Matthew-Curry/re-region-api/src/model"
 )
 
 var daoMock dao.DaoInterface
@@ -30,7 +30,7 @@ func setup() {
 	var err *apperrors.AppError = nil
 	daoMock = GetDaoMock()
 
-	federalService, err = services.GetFederalServiceImpl(daoMock)
+	federalService, err = services.GetFederalServiceImpl(daoMock, 0.03)
 	if err != nil {
 		log.Panic("Could not initialize federal service", err)
 	}
@@ -54,7 +54,7 @@ func assertEqual(t *testing.T, method string, a, b any) {
 
 func TestGetCountyById(t *testing.T) {
 
-	res, err := countyService.GetCountyById(5, "S", true, 4, 45000)
+	res, err := countyService.GetCountyById(5, model.FilerProfile{Filing_status: model.Single, Resident: true, Dependents: 4, Qualifying_children: 2, Income: 45000}, false)
 	if err != nil{
 		t.Error("Error recieved from the county service.", err)
 	}
@@ -64,7 +64,7 @@ func TestGetCountyById(t *testing.T) {
 
 
 func TestGetCountyByName(t *testing.T) {
-	res, err := countyService.GetCountyByName("name", "S", true, 4, 45000)
+	res, err := countyService.GetCountyByName("name", model.FilerProfile{Filing_status: model.Single, Resident: true, Dependents: 4, Qualifying_children: 2, Income: 45000}, false)
 	if err != nil{
 		t.Error("Error recieved from the county service.", err)
 	}
@@ -73,6 +73,44 @@ func TestGetCountyByName(t *testing.T) {
 }
 
 
+func TestGetPropertyTax(t *testing.T) {
+	assertEqual(t, "GetPropertyTax", model.GetPropertyTax(500000, 0.0098), 4900)
+	assertEqual(t, "GetPropertyTax", model.GetPropertyTax(0, 0.0098), 0)
+}
+
+func TestGetCountyByIdHomeValue(t *testing.T) {
+	res, err := countyService.GetCountyById(5, model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000,
+		Home_value: 500000}, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	assertEqual(t, "GetCountyById", res.Home_value, 500000)
+	assertEqual(t, "GetCountyById", res.Property_tax, 4400)
+}
+
+func TestGetCountyByIdDeductPropertyTax(t *testing.T) {
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 60000, Mortgage_interest: 10000,
+		Exclude_payroll: true}
+	res, err := countyService.GetCountyById(5, filer, true)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	filer.Deduct_property_tax = true
+	deducted, err := countyService.GetCountyById(5, filer, true)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	// the estimated property tax is added to the state and local tax paid
+	salt := res.Tax_locale[0].Breakdown.Federal.Itemized_deductions
+	deductedSalt := deducted.Tax_locale[0].Breakdown.Federal.Itemized_deductions
+	assertEqual(t, "GetCountyById", deductedSalt.Salt_paid - salt.Salt_paid, 8800)
+	assertEqual(t, "GetCountyById", deductedSalt.Salt, 10000)
+	assertEqual(t, "GetCountyById", deducted.Tax_locale[0].Federal_tax < res.Tax_locale[0].Federal_tax, true)
+}
+
 
 func TestGetCountyList(t *testing.T){
 	res, err := countyService.GetCountyList("metric", 5, true)
@@ -83,6 +121,73 @@ func TestGetCountyList(t *testing.T){
 	assertEqual(t, "GetCountyList", res, exCountyList)
 }
 
+func TestGetComputedCountyList(t *testing.T) {
+	filer := model.FilerProfile{Filing_status: model.Single, Income: 100000, Resident: true}
+	res, err := countyService.GetComputedCountyList("disposable_income", 5, true, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	// the counties pay the same taxes, so the lower rent of Westchester leaves the filer more income
+	ex := &model.ComputedCountyList{Metric_name: "disposable_income", Tax_year: 2022, Ranked_list: []model.ComputedCountyMetricPair{
+		{County_id: 36119, County_name: "Westchester County", State_id: 36, State_name: "New York", Metric_value: 46913},
+		{County_id: 36061, County_name: "New York County", State_id: 36, State_name: "New York", Locale_id: 3376,
+			Locale_name: "New York City", Metric_value: 45077},
+	}}
+	assertEqual(t, "GetComputedCountyList", res, ex)
+
+	// ties are ranked by county id, and the list is cut to the size
+	res, err = countyService.GetComputedCountyList("Total_Tax", 1, false, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetComputedCountyList", len(res.Ranked_list), 1)
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0].County_id, 36061)
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0].Metric_value, 33887.0)
+
+	res, err = countyService.GetComputedCountyList("effective_tax_rate", 5, true, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0].Metric_value, 0.3389)
+
+	// the city sales tax puts New York County above Westchester
+	res, err = countyService.GetComputedCountyList("sales_tax", 5, true, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0].County_id, 36061)
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0].Metric_value, 2418.0)
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[1].Metric_value, 1090.0)
+
+	// a cached list is ranked again by request, without the size or order of an earlier request
+	res, err = countyService.GetComputedCountyList("disposable_income", 5, false, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetComputedCountyList", len(res.Ranked_list), 2)
+	assertEqual(t, "GetComputedCountyList", res.Ranked_list[0], ex.Ranked_list[1])
+	res, err = countyService.GetComputedCountyList("total_tax", 5, false, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetComputedCountyList", len(res.Ranked_list), 2)
+
+	// census metrics are not computed
+	_, err = countyService.GetComputedCountyList("pop", 5, true, filer)
+	assertEqual(t, "GetComputedCountyList", err.IsKind(apperrors.DataNotFound), true)
+}
+
+func TestRankCountyList(t *testing.T) {
+	countyList := model.GetComputedCountyList("total_tax", 2022, false)
+	countyList.Ranked_list = []model.ComputedCountyMetricPair{{County_id: 3, Metric_value: 10}, {County_id: 2, Metric_value: 20},
+		{County_id: 1, Metric_value: 10}}
+	countyList.RankCountyList(2, true)
+
+	ex := []model.ComputedCountyMetricPair{{County_id: 2, Metric_value: 20}, {County_id: 1, Metric_value: 10}}
+	assertEqual(t, "RankCountyList", countyList.Ranked_list, ex)
+}
+
 func TestGetCountyTaxListById(t *testing.T){
 	res, err := countyService.GetCountyTaxListById(5)
 	if err != nil{
@@ -103,7 +208,7 @@ func TestGetCountyTaxListByName(t *testing.T) {
 
 
 func TestGetStateById(t *testing.T){
-	res, err := stateService.GetStateById(36, "M", 5, 45000)
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Married, Dependents: 5, Qualifying_children: 3, Income: 45000}, false)
 	if err != nil{
 		t.Error("Error recieved from the state service.", err)
 	}
@@ -113,7 +218,7 @@ func TestGetStateById(t *testing.T){
 
 
 func TestGetStateByName(t *testing.T) {
-	res, err := stateService.GetStateByName("New York", "M", 5, 45000)
+	res, err := stateService.GetStateByName("New York", model.FilerProfile{Filing_status: model.Married, Dependents: 5, Qualifying_children: 3, Income: 45000}, false)
 	if err != nil{
 		t.Error("Error recieved from the state service.", err)
 	}
@@ -136,7 +241,7 @@ func TestGetStateList(t *testing.T){
 
 
 func TestGetStateTaxInfoById(t *testing.T){
-	res, err := stateService.GetStateTaxInfoById(36)
+	res, err := stateService.GetStateTaxInfoById(36, 0)
 	if err != nil{
 		t.Error("Error recieved from the state service.", err)
 	}
@@ -150,7 +255,7 @@ func TestGetStateTaxInfoById(t *testing.T){
 
 
 func TestGetStateTaxInfoByName(t *testing.T){
-	res, err := stateService.GetStateTaxInfoByName("New York")
+	res, err := stateService.GetStateTaxInfoByName("New York", 0)
 	if err != nil{
 		t.Error("Error recieved from the state service.", err)
 	}
@@ -163,7 +268,7 @@ func TestGetStateTaxInfoByName(t *testing.T){
 
 
 func TestGetFederalTaxInfo(t *testing.T) {
-	res, err := federalService.GetFederalTaxInfo()
+	res, err := federalService.GetFederalTaxInfo(0)
 	if err != nil{
 		t.Error("Error recieved from the state service.", err)
 	}
@@ -173,4 +278,741 @@ func TestGetFederalTaxInfo(t *testing.T) {
 	federalTaxInfo.AppendToOrderedList(fb3)
 
 	assertEqual(t, "GetFederalTaxInfo", res, federalTaxInfo)
-}
\ No newline at end of file
+}
+
+func TestGetSingleTaxLiability(t *testing.T) {
+	res, err := federalService.GetFederalTaxInfo(0)
+	if err != nil{
+		t.Error("Error recieved from the federal service.", err)
+	}
+
+	tax, brackets := res.GetSingleTaxLiability(50000)
+
+	assertEqual(t, "GetSingleTaxLiability", tax, 6616)
+	assertEqual(t, "GetSingleTaxLiability", brackets, exSingleBrackets)
+}
+
+
+func TestGetSingleTaxLiabilityPriorYear(t *testing.T) {
+	res, err := federalService.GetFederalTaxInfo(2021)
+	if err != nil{
+		t.Error("Error recieved from the federal service.", err)
+	}
+
+	tax, _ := res.GetSingleTaxLiability(50000)
+
+	assertEqual(t, "GetSingleTaxLiability", res.Tax_year, 2021)
+	assertEqual(t, "GetSingleTaxLiability", res.Single_deduction, 12550)
+	assertEqual(t, "GetSingleTaxLiability", tax, 6748)
+}
+
+func TestGetTaxYearNotFound(t *testing.T) {
+	year, err := federalService.GetTaxYear(0)
+	if err != nil{
+		t.Error("Error recieved from the federal service.", err)
+	}
+	assertEqual(t, "GetTaxYear", year, 2022)
+
+	_, err = federalService.GetTaxYear(1999)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a tax year that is not loaded.", err)
+	}
+
+	// a year with brackets but without published federal parameters is not loaded
+	_, err = federalService.GetFederalTaxInfo(2020)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a tax year without federal parameters.", err)
+	}
+
+	_, err = stateService.GetStateTaxInfoById(36, 1999)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a tax year that is not loaded.", err)
+	}
+
+	// a year without state tax data is skipped by the state service rather than failing it
+	_, err = stateService.GetStateTaxInfoById(36, 2020)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a tax year without state tax data.", err)
+	}
+}
+
+func TestGetStateByIdPriorYear(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Income: 50000, Exclude_payroll: true}
+	current, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	filer.Tax_year = 2021
+	prior, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", prior.Tax_year, 2021)
+	assertEqual(t, "GetStateById", prior.State_tax, current.State_tax)
+	assertEqual(t, "GetStateById", current.Federal_tax, 4240)
+	assertEqual(t, "GetStateById", prior.Federal_tax, 4295)
+}
+
+func TestGetStateByIdFederalParametersByYear(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Income: 200000}
+	// the social security wage base of each published year, and the latest indexed for the projected year
+	for year, wageBase := range map[int]int{2021: 142800, 2022: 147000, 2023: 151400} {
+		filer.Tax_year = year
+		res, err := stateService.GetStateById(36, filer, true)
+		if err != nil{
+			t.Error("Error recieved from the state service.", err)
+		}
+		assertEqual(t, "GetStateById", res.Breakdown.Payroll.Social_security_wages, wageBase)
+	}
+
+	// the child credit of the prior year is fully refundable
+	filer = model.FilerProfile{Filing_status: model.Head, Dependents: 1, Qualifying_children: 1, Income: 15000, Exclude_payroll: true,
+		Tax_year: 2021}
+	res, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+	assertEqual(t, "GetStateById", res.Child_tax_credit, 3000)
+}
+
+func TestIndexAmount(t *testing.T) {
+	assertEqual(t, "IndexAmount", model.IndexAmount(10275, 1.03), 10550)
+	assertEqual(t, "IndexAmount", model.IndexAmount(12950, 1.0609), 13700)
+	assertEqual(t, "IndexAmount", model.IndexAmount(0, 1.03), 0)
+}
+
+func TestGetFederalTaxInfoProjected(t *testing.T) {
+	res, err := federalService.GetFederalTaxInfo(2023)
+	if err != nil{
+		t.Error("Error recieved from the federal service.", err)
+	}
+
+	tax, brackets := res.GetSingleTaxLiability(50000)
+
+	assertEqual(t, "GetFederalTaxInfo", res.Tax_year, 2023)
+	assertEqual(t, "GetFederalTaxInfo", res.Projected, true)
+	assertEqual(t, "GetFederalTaxInfo", res.Single_deduction, 13300)
+	assertEqual(t, "GetFederalTaxInfo", res.Married_deduction, 26650)
+	assertEqual(t, "GetFederalTaxInfo", brackets[1].Lower_bound, 10550)
+	assertEqual(t, "GetFederalTaxInfo", brackets[2].Lower_bound, 43000)
+	assertEqual(t, "GetFederalTaxInfo", tax, 6489)
+
+	// years past the projected years are not available
+	_, err = federalService.GetFederalTaxInfo(2026)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a tax year past the projected years.", err)
+	}
+}
+
+func TestGetStateTaxInfoProjected(t *testing.T) {
+	indexed, err := stateService.GetStateTaxInfoById(36, 2024)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateTaxInfoById", indexed.Projected, true)
+	assertEqual(t, "GetStateTaxInfoById", indexed.Single_deduction, 2650)
+	assertEqual(t, "GetStateTaxInfoById", indexed.Married_deduction, 7950)
+
+	// states that do not index keep the amounts of the latest published year
+	unindexed, err := stateService.GetStateTaxInfoByName("New Jersey", 2024)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateTaxInfoByName", unindexed.Projected, true)
+	assertEqual(t, "GetStateTaxInfoByName", unindexed.Single_exemption, 1000)
+	assertEqual(t, "GetStateTaxInfoByName", unindexed.Dependent_exemption, 1500)
+}
+
+func TestGetStateByIdProjected(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Income: 50000, Exclude_payroll: true}
+	published, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	filer.Tax_year = 2023
+	projected, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", published.Projected, false)
+	assertEqual(t, "GetStateById", projected.Projected, true)
+	assertEqual(t, "GetStateById", projected.Tax_year, 2023)
+	assertEqual(t, "GetStateById", projected.Federal_tax, 4193)
+}
+
+func TestGetStateByIdProgressive(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 10000, Exclude_payroll: true}, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", res, exStateProgressive)
+}
+
+
+func TestGetStateByIdExplain(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Dependents: 2, Income: 10000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", res.Breakdown, exStateBreakdown)
+	assertEqual(t, "GetStateById", res.Total_tax, -214)
+	assertEqual(t, "GetStateById", res.Net_refund, true)
+}
+
+
+func TestGetStateByIdPayroll(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 210000}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", res.Breakdown.Payroll, exPayrollBreakdown)
+	assertEqual(t, "GetStateById", res.Payroll_tax, 12249)
+	assertEqual(t, "GetStateById", res.Total_tax, res.Federal_tax+res.State_tax+res.Payroll_tax)
+}
+
+
+func TestGetStateByIdSelfEmployed(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 50000, Employment_type: model.SelfEmployed}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", res.Payroll_tax, 7064)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Adjustments, 3532)
+	assertEqual(t, "GetStateById", res.Quarterly_estimates, exQuarterlyEstimates)
+}
+
+
+func TestGetStateByIdHead(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Head, Income: 20000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// 20000 - 4000 deduction - 1500 exemption taxed at 0.02 up to 750 and 0.12 above
+	assertEqual(t, "GetStateById", res.State_tax, 1665)
+	assertEqual(t, "GetStateById", res.State_schedule_fallback, false)
+}
+
+
+func TestGetStateByIdSeparately(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.MarriedSeparately, Income: 20000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// federal separate schedule with the separate deduction, state falls back to the single schedule
+	assertEqual(t, "GetStateById", res.Federal_tax, 705)
+	assertEqual(t, "GetStateById", res.Breakdown.State.Schedule, model.FilingStatus(model.Single))
+	assertEqual(t, "GetStateById", res.State_schedule_fallback, true)
+}
+
+
+func TestGetStateByIdChildTaxCredit(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Dependents: 2, Qualifying_children: 2, Income: 30000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Credits[0], exChildTaxCredit)
+	assertEqual(t, "GetStateById", res.Child_tax_credit, 4000)
+	assertEqual(t, "GetStateById", res.Federal_tax, -6246)
+}
+
+
+func TestGetStateByIdDependentCreditPhaseOut(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Married, Dependents: 2, Qualifying_children: 1, Income: 410500, Exclude_payroll: true}, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// 11 steps over the married threshold reduce the child credit by 550
+	assertEqual(t, "GetStateById", res.Child_tax_credit, 1450)
+	assertEqual(t, "GetStateById", res.Other_dependent_credit, 500)
+}
+
+
+func TestGetStateByIdEarnedIncomeCredit(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Head, Dependents: 1, Qualifying_children: 1, Income: 15000, Exclude_payroll: true}, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// one child credit on the plateau, the state pays 30% of the federal credit
+	assertEqual(t, "GetStateById", res.Earned_income_credit, 3733)
+	assertEqual(t, "GetStateById", res.State_earned_income_credit, 1119)
+	assertEqual(t, "GetStateById", res.Federal_tax, -5233)
+}
+
+
+func TestGetStateByIdIraEarnedIncome(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Head, Dependents: 2, Qualifying_children: 2, Income: 22000, Exclude_payroll: true}
+	res, err := stateService.GetStateById(36, filer, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+	filer.Ira_contributions = 3000
+	ira, err := stateService.GetStateById(36, filer, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// the ira contribution lowers adjusted gross income, but is not taken from the earned income the credits are based on
+	assertEqual(t, "GetStateById", ira.Breakdown.Federal.Adjustments-res.Breakdown.Federal.Adjustments, 3000)
+	assertEqual(t, "GetStateById", ira.Earned_income_credit, 5771)
+	assertEqual(t, "GetStateById", ira.Earned_income_credit, res.Earned_income_credit)
+	assertEqual(t, "GetStateById", ira.Breakdown.Federal.Credits[0].Refundable, 2925)
+	assertEqual(t, "GetStateById", ira.Breakdown.Federal.Credits[0].Refundable, res.Breakdown.Federal.Credits[0].Refundable)
+}
+
+
+func TestGetStateByIdInvestmentIncome(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 50000, Long_term_gains: 300000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// gains stack on 37050 of ordinary taxable income, the state taxes them as ordinary income
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Preferential_brackets, exPreferentialBrackets)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Net_investment_income_tax, 5700)
+	assertEqual(t, "GetStateById", res.Federal_tax, 54246)
+	assertEqual(t, "GetStateById", res.Breakdown.State.Taxable_income, 346000)
+}
+
+
+func TestGetStateByIdPreTaxContributions(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 100000, Retirement_contributions: 25000,
+		Hsa_contributions: 5000, Section_125_contributions: 2000}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// retirement and hsa contributions are capped at the limits, only hsa and section 125 reduce payroll wages
+	assertEqual(t, "GetStateById", res.Breakdown.Contributions, exPreTaxContributions)
+	assertEqual(t, "GetStateById", res.Breakdown.Payroll.Wages, 94350)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Adjustments, 26150)
+	assertEqual(t, "GetStateById", res.Breakdown.State.Taxable_income, 69850)
+}
+
+
+func TestGetStateByIdItemized(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 200000, Mortgage_interest: 12000,
+		Charitable_gifts: 3000, Medical_expenses: 20000, Property_tax: 8000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// state tax and property tax are capped at 10000, medical expenses are deductible above 7.5% of income
+	assertEqual(t, "GetStateById", res.Federal_deduction_type, model.ItemizedDeduction)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Itemized_deductions.Salt, 10000)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Itemized_deductions.Medical_expenses, 5000)
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Taxable_income, 170000)
+}
+
+
+func TestGetStateByIdAlternativeMinimumTax(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 350000, Mortgage_interest: 30000,
+		Property_tax: 20000, Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// the capped state and local tax deduction is added back to income for the alternative minimum tax
+	assertEqual(t, "GetStateById", res.Breakdown.Federal.Alternative_minimum_tax, exAlternativeMinimumTax)
+	assertEqual(t, "GetStateById", res.Amt_tax, 410)
+}
+
+func TestGetTaxableSpending(t *testing.T) {
+	shares := []model.ConsumptionShare{{Income_bound: 0, Share: 0.35}, {Income_bound: 25000, Share: 0.30}}
+	assertEqual(t, "GetTaxableSpending", model.GetTaxableSpending(45000, shares), 14750)
+	assertEqual(t, "GetTaxableSpending", model.GetTaxableSpending(0, shares), 0)
+}
+
+func TestGetStateByIdSalesTaxSpending(t *testing.T){
+	res, err := stateService.GetStateById(36, model.FilerProfile{Filing_status: model.Single, Income: 45000, Annual_spending: 20000,
+		Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// given spending replaces the estimate from income
+	assertEqual(t, "GetStateById", res.Sales_tax, 800)
+	assertEqual(t, "GetStateById", res.Breakdown.Sales_tax.Spending_estimated, false)
+	assertEqual(t, "GetStateById", res.Breakdown.Sales_tax.Taxable_spending, 20000)
+}
+
+func TestGetStateListSalesTax(t *testing.T){
+	res, err := stateService.GetStateList("sales_tax", 1, true)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	exSalesTaxList := model.GetMetricStateList("sales_tax")
+	exSalesTaxList.AppendToRankedLists(model.StateMetricPair{State_id: 36, State_name: "New York", Metric_value: 892})
+	exSalesTaxList.SetRankedList(1, true)
+
+	assertEqual(t, "GetStateList", res, exSalesTaxList)
+}
+
+func TestGetDisposableIncome(t *testing.T) {
+	res := model.GetDisposableIncome(80000, 12000, 4000, 2000)
+	ex := model.DisposableIncome{Gross_income: 80000, Total_tax: 12000, Pre_tax_contributions: 4000, Annual_rent: 24000,
+		Disposable_income: 40000, Rent_to_income: 0.3, Tax_to_income: 0.15}
+	assertEqual(t, "GetDisposableIncome", res, ex)
+
+	// the ratios are left at 0 without income
+	res = model.GetDisposableIncome(0, 0, 0, 2000)
+	assertEqual(t, "GetDisposableIncome", res.Disposable_income, -24000)
+	assertEqual(t, "GetDisposableIncome", res.Rent_to_income, 0.0)
+}
+
+func TestGetComparison(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000, Exclude_payroll: true}
+	county, err := countyService.GetCountyById(5, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	state, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	origin, ok := model.GetCountyComparisonRegion(county, 3376)
+	assertEqual(t, "GetCountyComparisonRegion", ok, true)
+	_, ok = model.GetCountyComparisonRegion(county, 1)
+	assertEqual(t, "GetCountyComparisonRegion", ok, false)
+
+	res := model.GetComparison(origin, model.GetStateComparisonRegion(state), 2022, false)
+	assertEqual(t, "GetComparison", res.Origin.Locale_name, "New York City")
+	assertEqual(t, "GetComparison", res.Destination.Region_type, model.StateRegion)
+
+	// differences are from the origin to the destination, as a percent of the origin
+	popPercent := 1033.8
+	assertEqual(t, "GetComparison", res.Differences[0], model.MetricDifference{Metric: "pop", Origin: 1628706,
+		Destination: 18466230, Difference: 16837524, Percent_difference: &popPercent})
+	rentPercent := -21.22
+	assertEqual(t, "GetComparison", res.Differences[4], model.MetricDifference{Metric: "average_rent", Origin: 1753,
+		Destination: 1381, Difference: -372, Percent_difference: &rentPercent})
+	// the state has no locale tax
+	assertEqual(t, "GetComparison", res.Differences[9].Destination, 0)
+	assertEqual(t, "GetComparison", res.Differences[9].Difference, -res.Origin.Locale_tax)
+	// nor property tax
+	propertyPercent := -100.0
+	assertEqual(t, "GetComparison", res.Differences[12], model.MetricDifference{Metric: "property_tax", Origin: 8800,
+		Destination: 0, Difference: -8800, Percent_difference: &propertyPercent})
+
+	// credits are compared for a filer with a qualifying child
+	filer = model.FilerProfile{Filing_status: model.Head, Resident: true, Income: 30000, Dependents: 1, Qualifying_children: 1,
+		Exclude_payroll: true}
+	res = getComparison(t, filer)
+	assertEqual(t, "GetComparison", res.Differences[15].Metric, "child_tax_credit")
+	assertEqual(t, "GetComparison", res.Differences[15].Origin, 2000)
+	assertEqual(t, "GetComparison", res.Differences[17].Destination, 2156)
+	assertEqual(t, "GetComparison", res.Differences[18].Destination, 646)
+
+	// and the net investment income tax for a filer with investment income
+	filer = model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000, Interest: 200000, Exclude_payroll: true}
+	res = getComparison(t, filer)
+	assertEqual(t, "GetComparison", res.Differences[14].Metric, "net_investment_income_tax")
+	assertEqual(t, "GetComparison", res.Differences[14].Origin, 1710)
+	assertEqual(t, "GetComparison", res.Differences[14].Difference, 0)
+}
+
+// helper function to compare New York City to the state of New York for a filer
+func getComparison(t *testing.T, filer model.FilerProfile) *model.Comparison {
+	county, err := countyService.GetCountyById(5, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	state, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+	origin, _ := model.GetCountyComparisonRegion(county, 3376)
+
+	return model.GetComparison(origin, model.GetStateComparisonRegion(state), 2022, false)
+}
+
+func TestGetEquivalentIncome(t *testing.T) {
+	// a flat 25% tax and 24000 of rent
+	disposableAt := func(income int) (int, *apperrors.AppError) { return income*3/4 - 24000, nil }
+
+	res, found, _ := model.GetEquivalentIncome(51000, 50000, disposableAt)
+	assertEqual(t, "GetEquivalentIncome", found, true)
+	assertEqual(t, "GetEquivalentIncome", res, 100000)
+
+	// any income reaches a target below the disposable income without income
+	res, _, _ = model.GetEquivalentIncome(-30000, 50000, disposableAt)
+	assertEqual(t, "GetEquivalentIncome", res, 0)
+
+	_, found, _ = model.GetEquivalentIncome(100000000, 50000, disposableAt)
+	assertEqual(t, "GetEquivalentIncome", found, false)
+
+	// the search stops at an error estimating an income
+	failAt := func(income int) (int, *apperrors.AppError) {
+		if income > 60000 {
+			return 0, apperrors.StateIDNotFound(99)
+		}
+		return disposableAt(income)
+	}
+	_, found, err := model.GetEquivalentIncome(51000, 50000, failAt)
+	assertEqual(t, "GetEquivalentIncome", found, false)
+	assertEqual(t, "GetEquivalentIncome", err.IsKind(apperrors.DataNotFound), true)
+}
+
+func TestGetSalaryEquivalenceState(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000, Retirement_contributions: 2000,
+		Exclude_payroll: true}
+	county, err := countyService.GetCountyById(5, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	origin := model.GetDefaultCountyComparisonRegion(county)
+
+	res, found, err := stateService.GetSalaryEquivalenceById(36, origin, filer)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	// the lower state rent needs less income, and one dollar less falls short of the county disposable income
+	assertEqual(t, "GetSalaryEquivalenceById", found, true)
+	assertEqual(t, "GetSalaryEquivalenceById", res.Origin_income, 45000)
+	assertEqual(t, "GetSalaryEquivalenceById", res.Equivalent_income < 45000, true)
+	assertEqual(t, "GetSalaryEquivalenceById", res.Destination.Disposable_income >= origin.Disposable_income, true)
+	filer.Income = res.Equivalent_income - 1
+	state, _ := stateService.GetStateById(36, filer, false)
+	assertEqual(t, "GetSalaryEquivalenceById", state.Disposable_income.Disposable_income < origin.Disposable_income, true)
+
+	// disposable income is taken less the contributions taken from pay, as the net income of a gross up is
+	assertEqual(t, "GetSalaryEquivalenceById", state.Disposable_income.Pre_tax_contributions, 2000)
+	assertEqual(t, "GetSalaryEquivalenceById", state.Disposable_income.Disposable_income,
+		model.GetNetIncome(filer.Income, state.Total_tax, 2000)-state.Disposable_income.Annual_rent)
+}
+
+func TestGetSalaryEquivalenceCounty(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000, Exclude_payroll: true}
+	state, err := stateService.GetStateById(36, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+	origin := model.GetStateComparisonRegion(state)
+
+	// the higher county rent needs more income
+	res, found, err := countyService.GetSalaryEquivalenceById(5, 3376, true, origin, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetSalaryEquivalenceById", found, true)
+	assertEqual(t, "GetSalaryEquivalenceById", res.Equivalent_income > 45000, true)
+	assertEqual(t, "GetSalaryEquivalenceById", res.Destination.Locale_name, "New York City")
+	assertEqual(t, "GetSalaryEquivalenceById", res.Difference, res.Equivalent_income-45000)
+
+	// a tax locale not in the county is not found
+	_, _, err = countyService.GetSalaryEquivalenceById(5, 1, true, origin, filer)
+	assertEqual(t, "GetSalaryEquivalenceById", err.IsKind(apperrors.DataNotFound), true)
+}
+
+func TestGetGrossIncome(t *testing.T) {
+	// a flat 25% tax and a fee of 100 charged above 40000 of income
+	netAt := func(income int) (int, *apperrors.AppError) {
+		if income > 40000 {
+			return income*3/4 - 100, nil
+		}
+		return income * 3 / 4, nil
+	}
+
+	res, found, _ := model.GetGrossIncome(22500, netAt)
+	assertEqual(t, "GetGrossIncome", found, true)
+	assertEqual(t, "GetGrossIncome", res, 30000)
+
+	// net income falls when the fee is charged, the search finds the income past the fee where it crosses the target
+	res, _, _ = model.GetGrossIncome(30000, netAt)
+	assertEqual(t, "GetGrossIncome", res, 40134)
+}
+
+func TestGetGrossUpState(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Retirement_contributions: 2000, Exclude_payroll: true}
+	grossUp, found, err := stateService.GetGrossUpById(36, 52000, 1, filer)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+
+	assertEqual(t, "GetGrossUpById", found, true)
+	assertEqual(t, "GetGrossUpById", grossUp.Net_income, grossUp.Gross_income-grossUp.Region.Total_tax-2000)
+	assertEqual(t, "GetGrossUpById", grossUp.Net_income >= 52000, true)
+	assertEqual(t, "GetGrossUpById", grossUp.Within_tolerance, true)
+
+	// one dollar less falls short of the target
+	filer.Income = grossUp.Gross_income - 1
+	state, _ := stateService.GetStateById(36, filer, false)
+	assertEqual(t, "GetGrossUpById", model.GetNetIncome(filer.Income, state.Total_tax, 2000) < 52000, true)
+
+	grossUp.SetPaycheck(model.Monthly, 4333.34)
+	assertEqual(t, "GetGrossUp", grossUp.Pay_periods, 12)
+	assertEqual(t, "GetGrossUp", grossUp.Gross_pay, model.GetPerPeriodAmount(grossUp.Gross_income, 12))
+}
+
+func TestGetGrossUpContributionLimit(t *testing.T){
+	// only the contributions under the retirement limit are taken from pay
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Retirement_contributions: 30000, Exclude_payroll: true}
+	grossUp, _, err := stateService.GetGrossUpByName("New York", 52000, 1, filer)
+	if err != nil{
+		t.Error("Error recieved from the state service.", err)
+	}
+	assertEqual(t, "GetGrossUpByName", grossUp.Net_income, grossUp.Gross_income-grossUp.Region.Total_tax-20500)
+}
+
+func TestGetGrossUpCounty(t *testing.T){
+	filer := model.FilerProfile{Filing_status: model.Single, Resident: true, Exclude_payroll: true}
+	grossUp, found, err := countyService.GetGrossUpById(5, 3376, true, 52000, 1, filer)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	assertEqual(t, "GetGrossUpById", found, true)
+	assertEqual(t, "GetGrossUpById", grossUp.Region.Locale_name, "New York City")
+	assertEqual(t, "GetGrossUpById", grossUp.Net_income, grossUp.Gross_income-grossUp.Region.Total_tax)
+
+	// a tax locale not in the county is not found
+	_, _, err = countyService.GetGrossUpById(5, 1, true, 52000, 1, filer)
+	assertEqual(t, "GetGrossUpById", err.IsKind(apperrors.DataNotFound), true)
+}
+
+func TestGetCountyByIdSalesTax(t *testing.T){
+	res, err := countyService.GetCountyById(5, model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 45000,
+		Exclude_payroll: true}, true)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	// the locale rate is added to the state rate
+	salesTax := res.Tax_locale[0].Breakdown.Sales_tax
+	assertEqual(t, "GetCountyById", salesTax.Locale_rate, 0.04875)
+	assertEqual(t, "GetCountyById", salesTax.Tax, 1309)
+	assertEqual(t, "GetCountyById", res.Tax_locale[0].Sales_tax, 1309)
+}
+
+
+func TestGetPaycheckById(t *testing.T){
+	res, err := countyService.GetPaycheckById(5, model.FilerProfile{Filing_status: model.Single, Resident: true, Income: 52000,
+		Retirement_contributions: 5200, Pay_frequency: model.Weekly})
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	assertEqual(t, "GetPaycheckById", res.Pay_periods, 52)
+	assertEqual(t, "GetPaycheckById", res.Tax_locale[0], exPaycheckLocale)
+}
+
+func TestGetCommute(t *testing.T){
+	home, err := countyService.GetCountyTaxListById(5)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	// commute across state lines to a locale that taxes nonresidents
+	workLocale := home.Tax_locales[0]
+	workLocale.Locale_id = 3400
+	workLocale.Local_name = "Jersey City"
+	workLocale.Nonresident_rate = 0.01
+	work := &model.CountyTaxList{County_id: 34017, County_name: "Hudson County", State_id: 34, State_name: "New Jersey",
+		Tax_locales: []model.TaxLocaleInfo{workLocale}}
+
+	res, err := countyService.GetCommute(home, work, model.FilerProfile{Filing_status: model.Single, Income: 60000, Interest: 15000,
+		Exclude_payroll: true}, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	assertEqual(t, "GetCommute", res.Tax_locale[0], exCommuteLocale)
+}
+
+func TestGetCommuteReciprocity(t *testing.T){
+	home, err := countyService.GetCountyTaxListById(5)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	// live in New Jersey and work in Pennsylvania, which only taxes the wages of New Jersey residents at home
+	homeLocale := home.Tax_locales[0]
+	homeLocale.Locale_id = 3400
+	homeLocale.Local_name = "Cherry Hill"
+	njHome := &model.CountyTaxList{County_id: 34007, County_name: "Camden County", State_id: 34, State_name: "New Jersey",
+		Tax_locales: []model.TaxLocaleInfo{homeLocale}}
+	paWork := &model.CountyTaxList{County_id: 42101, County_name: "Philadelphia County", State_id: 42, State_name: "Pennsylvania",
+		Tax_locales: home.Tax_locales}
+
+	filer := model.FilerProfile{Filing_status: model.Single, Income: 60000, Exclude_payroll: true}
+	res, err := countyService.GetCommute(njHome, paWork, filer, true)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	assertEqual(t, "GetCommute", res.Reciprocity, true)
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Work_state_tax, 0)
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Other_state_credit, 0)
+	// 60000 - 1000 exemption at 0.02
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Home_state_tax, 1180)
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Breakdown.Earners[0].Work_state, (*model.IncomeTaxBreakdown)(nil))
+
+	// agreements do not cover self-employment earnings
+	filer.Employment_type = model.SelfEmployed
+	res, err = countyService.GetCommute(njHome, paWork, filer, false)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+
+	assertEqual(t, "GetCommute", res.Reciprocity, false)
+	// 60000 at 0.03, the credit is limited to the home state tax on the same income
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Work_state_tax, 1800)
+	assertEqual(t, "GetCommute", res.Tax_locale[0].Other_state_credit, 1180)
+}
+
+func TestGetCommuteWorkStateNotInTaxCache(t *testing.T){
+	home, err := countyService.GetCountyTaxListById(5)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	// a work state without tax data is not found rather than estimated
+	work := &model.CountyTaxList{County_id: 99001, County_name: "Unknown County", State_id: 99, State_name: "Unknown",
+		Tax_locales: home.Tax_locales}
+
+	_, err = countyService.GetCommute(home, work, model.FilerProfile{Filing_status: model.Single, Income: 60000}, false)
+	if err == nil || !err.IsKind(apperrors.DataNotFound) {
+		t.Error("Expected a data not found error for a work state not in the state tax cache.", err)
+	}
+}
+
+func TestGetHousehold(t *testing.T){
+	home, err := countyService.GetCountyTaxListById(5)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	// the spouse works across state lines in a locale charging nonresidents a wage tax and an annual fee
+	workLocale := home.Tax_locales[0]
+	workLocale.Locale_id = 3400
+	workLocale.Local_name = "Jersey City"
+	workLocale.Nonresident_rate = 0.01
+	workLocale.Nonresident_year_fee = 52
+	work := &model.CountyTaxList{County_id: 34017, County_name: "Hudson County", State_id: 34, State_name: "New Jersey",
+		Tax_locales: []model.TaxLocaleInfo{workLocale}}
+	earners := []model.EarnerProfile{{Income: 150000, Work: home}, {Income: 120000, Work: work}}
+
+	res, err := countyService.GetHousehold(home, earners, model.FilerProfile{Filing_status: model.Married}, true)
+	if err != nil{
+		t.Error("Error recieved from the county service.", err)
+	}
+	// joint state tax less the credit for the spouse's work state tax, local and payroll taxes are charged per earner
+	assertEqual(t, "GetHousehold", res.Tax_locale[0].State_tax, 28658)
+	assertEqual(t, "GetHousehold", res.Tax_locale[0].Earners, exHouseholdEarners)
+	assertEqual(t, "GetHousehold", res.Tax_locale[0].Total_tax, 97877)
+	// the additional medicare threshold of the joint return is split by the wages of each earner
+	assertEqual(t, "GetHousehold", res.Tax_locale[0].Breakdown.Earners[0].Payroll.Additional_medicare_tax+
+		res.Tax_locale[0].Breakdown.Earners[1].Payroll.Additional_medicare_tax, 180)
+}